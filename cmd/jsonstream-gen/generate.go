@@ -0,0 +1,517 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	jreaderImportPath = "github.com/launchdarkly/go-jsonstream/v3/jreader"
+	jwriterImportPath = "github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+// basicTypeInfo describes how a Go basic type is read and written. If readMethod is the name of a
+// Reader method that returns exactly this type, it can be used directly; otherwise, the value
+// returned by readMethod is converted to the type. Integer types have no readMethod, because they
+// are read with jreader.ReadInteger, which checks that the value is in range for the type.
+type basicTypeInfo struct {
+	readMethod  string // e.g. "String" for Reader.String
+	writeMethod string // e.g. "String" for Writer.String
+	methodType  string // the Go type taken/returned by those methods
+	kind        basicKind
+	bitSize     int // the bitSize parameter for strconv parsing functions with the ",string" option
+}
+
+type basicKind int
+
+const (
+	boolKind   basicKind = iota
+	stringKind basicKind = iota
+	intKind    basicKind = iota
+	uintKind   basicKind = iota
+	floatKind  basicKind = iota
+)
+
+var basicTypes = map[string]basicTypeInfo{ //nolint:gochecknoglobals
+	"bool":    {"Bool", "Bool", "bool", boolKind, 0},
+	"string":  {"String", "String", "string", stringKind, 0},
+	"int":     {"", "Int", "int", intKind, 0},
+	"int8":    {"", "Int", "int", intKind, 8},
+	"int16":   {"", "Int", "int", intKind, 16},
+	"int32":   {"", "Int", "int", intKind, 32},
+	"rune":    {"", "Int", "int", intKind, 32},
+	"int64":   {"", "Int64", "int64", intKind, 64},
+	"uint8":   {"", "Int", "int", uintKind, 8},
+	"byte":    {"", "Int", "int", uintKind, 8},
+	"uint16":  {"", "Int", "int", uintKind, 16},
+	"uint32":  {"", "Uint64", "uint64", uintKind, 32},
+	"uint":    {"", "Uint64", "uint64", uintKind, 0},
+	"uint64":  {"", "Uint64", "uint64", uintKind, 64},
+	"float32": {"Float32", "Float32", "float32", floatKind, 32},
+	"float64": {"Float64", "Float64", "float64", floatKind, 64},
+}
+
+type generator struct {
+	pkg          *packageInfo
+	buf          bytes.Buffer
+	usedImports  map[string]string
+	usesStrconv  bool
+	resolveDepth int
+}
+
+// generate produces the formatted source code of a Go file that implements jreader.Readable and
+// jwriter.Writable for the specified structs.
+func generate(pkg *packageInfo, structs []structInfo) ([]byte, error) {
+	g := &generator{pkg: pkg, usedImports: make(map[string]string)}
+	var body bytes.Buffer
+	for _, si := range structs {
+		if err := g.generateStruct(si); err != nil {
+			return nil, err
+		}
+		body.Write(g.buf.Bytes())
+		g.buf.Reset()
+	}
+
+	g.printf("// Code generated by jsonstream-gen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg.name)
+	var stdImports, otherImports []string
+	if g.usesStrconv {
+		stdImports = append(stdImports, `"strconv"`)
+	}
+	otherImports = append(otherImports, strconv.Quote(jreaderImportPath), strconv.Quote(jwriterImportPath))
+	for name, path := range g.usedImports {
+		spec := strconv.Quote(path)
+		if path[strings.LastIndex(path, "/")+1:] != name {
+			spec = name + " " + spec
+		}
+		if strings.Contains(path, ".") {
+			otherImports = append(otherImports, spec)
+		} else {
+			stdImports = append(stdImports, spec)
+		}
+	}
+	sort.Strings(stdImports)
+	sort.Strings(otherImports)
+	g.printf("import (\n")
+	if len(stdImports) != 0 {
+		g.printf("%s\n\n", strings.Join(stdImports, "\n"))
+	}
+	g.printf("%s\n)\n", strings.Join(otherImports, "\n"))
+	g.buf.Write(body.Bytes())
+
+	formatted, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("internal error, generated code was invalid: %w", err) // COVERAGE: should not happen
+	}
+	return formatted, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generateStruct(si structInfo) error {
+	var requiredNames []string
+	for _, f := range si.fields {
+		if f.required {
+			requiredNames = append(requiredNames, strconv.Quote(f.jsonName))
+		}
+	}
+	requiredVar := "jsonstreamRequiredProps" + si.name
+	if len(requiredNames) != 0 {
+		g.printf("\nvar %s = []string{%s}\n", requiredVar, strings.Join(requiredNames, ", "))
+	}
+
+	g.printf("\n// WriteToJSONWriter writes the %s as a JSON object. It implements jwriter.Writable.\n", si.name)
+	g.printf("func (x %s) WriteToJSONWriter(w *jwriter.Writer) {\n", si.name)
+	g.printf("obj := w.Object()\n")
+	for _, f := range si.fields {
+		if err := g.writeField(si, f); err != nil {
+			return err
+		}
+	}
+	g.printf("obj.End()\n}\n")
+
+	g.printf("\n// ReadFromJSONReader reads the %s from a JSON object. It implements jreader.Readable.\n", si.name)
+	g.printf("func (x *%s) ReadFromJSONReader(r *jreader.Reader) {\n", si.name)
+	objectExpr := "r.Object()"
+	if len(requiredNames) != 0 {
+		objectExpr += ".WithRequiredProperties(" + requiredVar + ")"
+	}
+	if len(si.fields) == 0 {
+		g.printf("for obj := %s; obj.Next(); {\n}\n}\n", objectExpr)
+		return nil
+	}
+	g.printf("for obj := %s; obj.Next(); {\n", objectExpr)
+	g.printf("switch string(obj.Name()) {\n")
+	for _, f := range si.fields {
+		g.printf("case %s:\n", strconv.Quote(f.jsonName))
+		if err := g.readField(si, f); err != nil {
+			return err
+		}
+	}
+	g.printf("}\n}\n}\n")
+	return nil
+}
+
+func (g *generator) writeField(si structInfo, f fieldInfo) error {
+	target := "x." + f.goName
+	name := strconv.Quote(f.jsonName)
+	if f.asString {
+		basic, ok := g.resolveBasic(f.typ)
+		if !ok || basic.kind == stringKind {
+			return g.fieldError(si, f, `the ",string" option is only supported for numeric and boolean types`)
+		}
+		g.usesStrconv = true
+		var formatExpr string
+		switch basic.kind {
+		case boolKind:
+			formatExpr = "strconv.FormatBool(" + convert("bool", f.typ, target) + ")"
+		case intKind:
+			formatExpr = "strconv.FormatInt(" + convert("int64", f.typ, target) + ", 10)"
+		case uintKind:
+			formatExpr = "strconv.FormatUint(" + convert("uint64", f.typ, target) + ", 10)"
+		default:
+			formatExpr = fmt.Sprintf("strconv.FormatFloat(%s, 'g', -1, %d)", convert("float64", f.typ, target), basic.bitSize)
+		}
+		writerExpr := "obj.Name(" + name + ")"
+		if f.omitEmpty {
+			writerExpr = "obj.Maybe(" + name + ", " + g.nonEmptyCondition(f.typ, target) + ")"
+		}
+		g.printf("%s.String(%s)\n", writerExpr, formatExpr)
+		return nil
+	}
+	if _, isPointer := f.typ.(*ast.StarExpr); isPointer {
+		stmt, err := g.writeValueStmt("obj.Name("+name+")", target, f.typ)
+		if err != nil {
+			return g.fieldError(si, f, err.Error())
+		}
+		if f.omitEmpty {
+			// the pointer is known to be non-nil, so we can skip the null check
+			stmt, _ = g.writeValueStmt("obj.Name("+name+")", "*"+target, f.typ.(*ast.StarExpr).X)
+			g.printf("if %s != nil {\n%s\n}\n", target, stmt)
+		} else {
+			g.printf("%s\n", stmt)
+		}
+		return nil
+	}
+	writerExpr := "obj.Name(" + name + ")"
+	if f.omitEmpty {
+		if cond := g.nonEmptyCondition(f.typ, target); cond != "" {
+			writerExpr = "obj.Maybe(" + name + ", " + cond + ")"
+		}
+	}
+	stmt, err := g.writeValueStmt(writerExpr, target, f.typ)
+	if err != nil {
+		return g.fieldError(si, f, err.Error())
+	}
+	g.printf("%s\n", stmt)
+	return nil
+}
+
+func (g *generator) readField(si structInfo, f fieldInfo) error {
+	target := "x." + f.goName
+	if f.asString {
+		basic, _ := g.resolveBasic(f.typ) // we already validated this in writeField
+		var parseExpr string
+		switch basic.kind {
+		case boolKind:
+			parseExpr = "strconv.ParseBool(r.String())"
+		case intKind:
+			parseExpr = fmt.Sprintf("strconv.ParseInt(r.String(), 10, %d)", basic.bitSize)
+		case uintKind:
+			parseExpr = fmt.Sprintf("strconv.ParseUint(r.String(), 10, %d)", basic.bitSize)
+		default:
+			parseExpr = fmt.Sprintf("strconv.ParseFloat(r.String(), %d)", basic.bitSize)
+		}
+		g.printf("if v, err := %s; err != nil {\nr.AddError(err)\n} else {\n%s = %s\n}\n",
+			parseExpr, target, convertTo(g.typeString(f.typ), "v", parseResultType(basic.kind)))
+		return nil
+	}
+	if ptr, isPointer := f.typ.(*ast.StarExpr); isPointer {
+		if basic, ok := g.resolveBasic(ptr.X); ok && basic.readMethod != "" {
+			elemType := g.typeString(ptr.X)
+			g.printf("if v, ok := r.%sOrNull(); ok {\n", basic.readMethod)
+			if elemType == basic.methodType {
+				g.printf("%s = &v\n", target)
+			} else {
+				g.printf("value := %s(v)\n%s = &value\n", elemType, target)
+			}
+			g.printf("} else {\n%s = nil\n}\n", target)
+			return nil
+		}
+		readFunc, err := g.readFunc(ptr.X)
+		if err != nil {
+			return g.fieldError(si, f, err.Error())
+		}
+		g.printf("if v, ok := jreader.ReadOptional(r, %s); ok {\n%s = &v\n} else {\n%s = nil\n}\n",
+			readFunc, target, target)
+		return nil
+	}
+	expr, err := g.readValueExpr(f.typ)
+	if err != nil {
+		return g.fieldError(si, f, err.Error())
+	}
+	g.printf("%s = %s\n", target, expr)
+	return nil
+}
+
+// readValueExpr returns a Go expression of the specified type that reads a value from the Reader r.
+func (g *generator) readValueExpr(t ast.Expr) (string, error) {
+	if basic, ok := g.resolveBasic(t); ok {
+		if basic.readMethod == "" {
+			return "jreader.ReadInteger[" + g.typeString(t) + "](r)", nil
+		}
+		return convertTo(g.typeString(t), "r."+basic.readMethod+"()", basic.methodType), nil
+	}
+	switch t := t.(type) {
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "", fmt.Errorf("arrays are not supported, use a slice")
+		}
+		if g.isByteType(t.Elt) {
+			return "", fmt.Errorf("byte slices are not supported")
+		}
+		elemFunc, err := g.readFunc(t.Elt)
+		if err != nil {
+			return "", err
+		}
+		return "jreader.ReadSlice(r, " + elemFunc + ")", nil
+	case *ast.MapType:
+		if _, ok := g.resolveBasic(t.Key); !ok || !g.isStringType(t.Key) {
+			return "", fmt.Errorf("maps are only supported if the key type is string")
+		}
+		valueFunc, err := g.readFunc(t.Value)
+		if err != nil {
+			return "", err
+		}
+		keyType := g.typeString(t.Key)
+		if keyType == "string" {
+			return "jreader.ReadMap(r, " + valueFunc + ")", nil
+		}
+		// ReadMap always returns a map[string]T, so for a named key type we convert the keys.
+		mapType := g.typeString(t)
+		return fmt.Sprintf("func(r *jreader.Reader) %s {\nm := jreader.ReadMap(r, %s)\nif m == nil {\nreturn nil\n}\n"+
+			"ret := make(%s, len(m))\nfor k, v := range m {\nret[%s(k)] = v\n}\nreturn ret\n}(r)",
+			mapType, valueFunc, mapType, keyType), nil
+	case *ast.StarExpr:
+		readFunc, err := g.readFunc(t)
+		if err != nil {
+			return "", err
+		}
+		return readFunc + "(r)", nil
+	}
+	if err := g.checkReadableType(t); err != nil {
+		return "", err
+	}
+	return "jreader.ReadReadable[" + g.typeString(t) + "](r)", nil
+}
+
+// readFunc returns a Go expression for a function of type func(*jreader.Reader) T, where T is the
+// specified type.
+func (g *generator) readFunc(t ast.Expr) (string, error) {
+	if basic, ok := g.resolveBasic(t); ok {
+		if basic.readMethod == "" {
+			return "jreader.ReadInteger[" + g.typeString(t) + "]", nil
+		}
+		if g.typeString(t) == basic.methodType {
+			return "(*jreader.Reader)." + basic.readMethod, nil
+		}
+	}
+	typeName := g.typeString(t)
+	if ptr, ok := t.(*ast.StarExpr); ok {
+		elemFunc, err := g.readFunc(ptr.X)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("func(r *jreader.Reader) %s {\nif v, ok := jreader.ReadOptional(r, %s); ok {\n"+
+			"return &v\n}\nreturn nil\n}", typeName, elemFunc), nil
+	}
+	expr, err := g.readValueExpr(t)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(expr, "jreader.ReadReadable[") {
+		return strings.TrimSuffix(expr, "(r)"), nil
+	}
+	return fmt.Sprintf("func(r *jreader.Reader) %s {\nreturn %s\n}", typeName, expr), nil
+}
+
+// writeValueStmt returns a Go statement that writes a value of the specified type, using the
+// specified Writer expression.
+func (g *generator) writeValueStmt(writerExpr, valueExpr string, t ast.Expr) (string, error) {
+	if basic, ok := g.resolveBasic(t); ok {
+		return writerExpr + "." + basic.writeMethod + "(" + convert(basic.methodType, t, valueExpr) + ")", nil
+	}
+	switch t := t.(type) {
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "", fmt.Errorf("arrays are not supported, use a slice")
+		}
+		if g.isByteType(t.Elt) {
+			return "", fmt.Errorf("byte slices are not supported")
+		}
+		elemFunc, err := g.writeFunc(t.Elt)
+		if err != nil {
+			return "", err
+		}
+		return "jwriter.WriteSlice(" + writerExpr + ", " + valueExpr + ", " + elemFunc + ")", nil
+	case *ast.MapType:
+		if _, ok := g.resolveBasic(t.Key); !ok || !g.isStringType(t.Key) {
+			return "", fmt.Errorf("maps are only supported if the key type is string")
+		}
+		valueFunc, err := g.writeFunc(t.Value)
+		if err != nil {
+			return "", err
+		}
+		return "jwriter.WriteMap(" + writerExpr + ", " + valueExpr + ", " + valueFunc + ")", nil
+	case *ast.StarExpr:
+		elemStmt, err := g.writeValueStmt(writerExpr, "*"+valueExpr, t.X)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("if %s == nil {\n%s.Null()\n} else {\n%s\n}", valueExpr, writerExpr, elemStmt), nil
+	}
+	if err := g.checkReadableType(t); err != nil {
+		return "", err
+	}
+	// If we have dereferenced a pointer, there is no need to do so explicitly, since WriteToJSONWriter
+	// is assumed to have a value receiver.
+	valueExpr = strings.TrimPrefix(valueExpr, "*")
+	return valueExpr + ".WriteToJSONWriter(" + writerExpr + ")", nil
+}
+
+// writeFunc returns a Go expression for a function of type func(*jwriter.Writer, T), where T is the
+// specified type.
+func (g *generator) writeFunc(t ast.Expr) (string, error) {
+	if basic, ok := g.resolveBasic(t); ok && g.typeString(t) == basic.methodType {
+		return "(*jwriter.Writer)." + basic.writeMethod, nil
+	}
+	switch t.(type) {
+	case *ast.ArrayType, *ast.MapType, *ast.StarExpr:
+	default:
+		if _, isBasic := g.resolveBasic(t); !isBasic {
+			if err := g.checkReadableType(t); err != nil {
+				return "", err
+			}
+			return "jwriter.WriteWritable[" + g.typeString(t) + "]", nil
+		}
+	}
+	stmt, err := g.writeValueStmt("w", "v", t)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("func(w *jwriter.Writer, v %s) {\n%s\n}", g.typeString(t), stmt), nil
+}
+
+// nonEmptyCondition returns a Go boolean expression that is true if the value is not empty in the
+// sense used by the "omitempty" option, or "" if values of this type are never considered empty.
+func (g *generator) nonEmptyCondition(t ast.Expr, valueExpr string) string {
+	if basic, ok := g.resolveBasic(t); ok {
+		switch basic.kind {
+		case boolKind:
+			return valueExpr
+		case stringKind:
+			return valueExpr + ` != ""`
+		default:
+			return valueExpr + " != 0"
+		}
+	}
+	switch t.(type) {
+	case *ast.ArrayType, *ast.MapType:
+		return "len(" + valueExpr + ") != 0"
+	case *ast.StarExpr:
+		return valueExpr + " != nil"
+	}
+	return ""
+}
+
+// resolveBasic determines whether a type is a basic type, or a locally declared named type whose
+// underlying type is a basic type.
+func (g *generator) resolveBasic(t ast.Expr) (basicTypeInfo, bool) {
+	ident, ok := t.(*ast.Ident)
+	if !ok {
+		return basicTypeInfo{}, false
+	}
+	if spec, ok := g.pkg.localTypes[ident.Name]; ok {
+		if g.resolveDepth > 10 { // a recursive type definition, which would not compile anyway
+			return basicTypeInfo{}, false
+		}
+		g.resolveDepth++
+		defer func() { g.resolveDepth-- }()
+		return g.resolveBasic(spec.Type)
+	}
+	basic, ok := basicTypes[ident.Name]
+	return basic, ok
+}
+
+func (g *generator) isStringType(t ast.Expr) bool {
+	basic, ok := g.resolveBasic(t)
+	return ok && basic.kind == stringKind
+}
+
+func (g *generator) isByteType(t ast.Expr) bool {
+	ident, ok := t.(*ast.Ident)
+	return ok && (ident.Name == "byte" || ident.Name == "uint8")
+}
+
+// checkReadableType verifies that a type is one that can be assumed to implement Readable and
+// Writable: either a local named type, or a named type from another package.
+func (g *generator) checkReadableType(t ast.Expr) error {
+	switch t := t.(type) {
+	case *ast.Ident:
+		if _, ok := g.pkg.localTypes[t.Name]; ok {
+			return nil
+		}
+	case *ast.SelectorExpr:
+		if pkgIdent, ok := t.X.(*ast.Ident); ok {
+			if path, ok := g.pkg.imports[pkgIdent.Name]; ok {
+				g.usedImports[pkgIdent.Name] = path
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("type %s is not supported", types.ExprString(t))
+}
+
+func (g *generator) typeString(t ast.Expr) string {
+	return types.ExprString(t)
+}
+
+func (g *generator) fieldError(si structInfo, f fieldInfo, message string) error {
+	return fmt.Errorf("%s.%s: %s", si.name, f.goName, message)
+}
+
+// convert returns an expression that converts valueExpr from type t to the named type, omitting
+// the conversion if it is already of that type.
+func convert(toType string, t ast.Expr, valueExpr string) string {
+	return convertTo(toType, valueExpr, types.ExprString(t))
+}
+
+// convertTo returns an expression that converts valueExpr from fromType to toType, omitting the
+// conversion if the types are the same.
+func convertTo(toType, valueExpr, fromType string) string {
+	if toType == fromType {
+		return valueExpr
+	}
+	return toType + "(" + valueExpr + ")"
+}
+
+func parseResultType(kind basicKind) string {
+	switch kind {
+	case boolKind:
+		return "bool"
+	case intKind:
+		return "int64"
+	case uintKind:
+		return "uint64"
+	default:
+		return "float64"
+	}
+}
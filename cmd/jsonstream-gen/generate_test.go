@@ -0,0 +1,212 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateFromSource(t *testing.T, source string, typeNames ...string) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", source, 0)
+	require.NoError(t, err)
+	pkg := parsePackage(file.Name.Name, []*ast.File{file})
+	structs, err := pkg.selectStructs(typeNames)
+	if err != nil {
+		return "", err
+	}
+	output, err := generate(pkg, structs)
+	return string(output), err
+}
+
+func TestGeneratedExampleIsUpToDate(t *testing.T) {
+	// If this test fails, run "go generate" in internal/example and check the changes to the output.
+	dir := filepath.Join("internal", "example")
+	outputFile := filepath.Join(dir, defaultOutputFileName)
+	expected, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+
+	pkg, err := loadPackage(dir, outputFile)
+	require.NoError(t, err)
+	structs, err := pkg.selectStructs([]string{"Flag", "Variation", "Target", "Empty", "Limits"})
+	require.NoError(t, err)
+	output, err := generate(pkg, structs)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(output))
+}
+
+func TestRunWritesOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	source := "package p\n\ntype A struct {\n\tName string `json:\"name\"`\n}\n\ntype B struct{}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "p.go"), []byte(source), 0600))
+
+	require.NoError(t, run(dir, "", ""))
+	output, err := os.ReadFile(filepath.Join(dir, defaultOutputFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "func (x A) WriteToJSONWriter(")
+	assert.Contains(t, string(output), "func (x *B) ReadFromJSONReader(")
+
+	// running it again should ignore the previous output file rather than seeing duplicate methods
+	otherOutput := filepath.Join(dir, "other.go")
+	require.NoError(t, run(dir, otherOutput, "B"))
+	output, err = os.ReadFile(otherOutput)
+	require.NoError(t, err)
+	assert.NotContains(t, string(output), "func (x A)")
+	assert.Contains(t, string(output), "func (x B) WriteToJSONWriter(")
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	assert.Error(t, run(dir, "", "")) // no files
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "p.go"), []byte("package p\n\ntype A int\n"), 0600))
+	assert.Error(t, run(dir, "", ""))  // no structs
+	assert.Error(t, run(dir, "", "A")) // not a struct
+	assert.Error(t, run(dir, "", "B")) // not found
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "q.go"), []byte("package q\n"), 0600))
+	assert.Error(t, run(dir, "", "")) // two packages
+}
+
+func TestFieldNamesAndOptions(t *testing.T) {
+	output, err := generateFromSource(t, `package p
+type S struct {
+	A     string `+"`json:\"a\" jsonstream:\"required\"`"+`
+	B     int    `+"`json:\",omitempty\"`"+`
+	C     uint   `+"`json:\"c,string\"`"+`
+	D     string `+"`json:\"-\"`"+`
+	e     string
+	F, G  bool
+}`)
+	require.NoError(t, err)
+	assert.Contains(t, output, `var jsonstreamRequiredPropsS = []string{"a"}`)
+	assert.Contains(t, output, `r.Object().WithRequiredProperties(jsonstreamRequiredPropsS)`)
+	assert.Contains(t, output, `obj.Maybe("B", x.B != 0).Int(x.B)`)
+	assert.Contains(t, output, `obj.Name("c").String(strconv.FormatUint(uint64(x.C), 10))`)
+	assert.Contains(t, output, `strconv.ParseUint(r.String(), 10, 0)`)
+	assert.Contains(t, output, `x.C = uint(v)`)
+	assert.NotContains(t, output, `"D"`)
+	assert.NotContains(t, output, `"e"`)
+	assert.Contains(t, output, `obj.Name("F").Bool(x.F)`)
+	assert.Contains(t, output, `obj.Name("G").Bool(x.G)`)
+}
+
+func TestIntegerTypes(t *testing.T) {
+	output, err := generateFromSource(t, `package p
+type Count uint32
+type S struct {
+	A int8
+	B int64
+	C uint
+	D Count
+	E *int16
+	F map[string]uint64
+	G int32 `+"`json:\",string\"`"+`
+}`)
+	require.NoError(t, err)
+	assert.Contains(t, output, `obj.Name("A").Int(int(x.A))`)
+	assert.Contains(t, output, `x.A = jreader.ReadInteger[int8](r)`)
+	assert.Contains(t, output, `obj.Name("B").Int64(x.B)`)
+	assert.Contains(t, output, `x.B = jreader.ReadInteger[int64](r)`)
+	assert.Contains(t, output, `obj.Name("C").Uint64(uint64(x.C))`)
+	assert.Contains(t, output, `x.C = jreader.ReadInteger[uint](r)`)
+	assert.Contains(t, output, `obj.Name("D").Uint64(uint64(x.D))`)
+	assert.Contains(t, output, `x.D = jreader.ReadInteger[Count](r)`)
+	assert.Contains(t, output, `jreader.ReadOptional(r, jreader.ReadInteger[int16])`)
+	assert.Contains(t, output, `jreader.ReadMap(r, jreader.ReadInteger[uint64])`)
+	assert.Contains(t, output, `strconv.ParseInt(r.String(), 10, 32)`)
+}
+
+func TestFloat32(t *testing.T) {
+	output, err := generateFromSource(t, `package p
+type S struct {
+	A float32
+	B *float32
+	C float32 `+"`json:\",string\"`"+`
+}`)
+	require.NoError(t, err)
+	assert.Contains(t, output, `obj.Name("A").Float32(x.A)`)
+	assert.Contains(t, output, `x.A = r.Float32()`)
+	assert.Contains(t, output, `r.Float32OrNull()`)
+	assert.Contains(t, output, `strconv.FormatFloat(float64(x.C), 'g', -1, 32)`)
+	assert.Contains(t, output, `strconv.ParseFloat(r.String(), 32)`)
+}
+
+func TestMapKeyTypes(t *testing.T) {
+	output, err := generateFromSource(t, `package p
+type Key string
+type OtherKey Key
+type S struct {
+	A map[Key]int
+	B map[OtherKey]*Key
+}`)
+	require.NoError(t, err)
+	assert.Contains(t, output, `jwriter.WriteMap(obj.Name("A"), x.A, (*jwriter.Writer).Int)`)
+	assert.Contains(t, output, `x.A = func(r *jreader.Reader) map[Key]int {`)
+	assert.Contains(t, output, `ret[Key(k)] = v`)
+	assert.Contains(t, output, `ret[OtherKey(k)] = v`)
+
+	_, err = generateFromSource(t, "package p\ntype ID int\ntype S struct {\n\tA map[ID]string\n}\n")
+	assert.Error(t, err)
+}
+
+func TestImportedTypes(t *testing.T) {
+	output, err := generateFromSource(t, `package p
+import (
+	"time"
+	other "example.com/some/pkg"
+)
+type S struct {
+	A other.Thing
+	B []*other.Thing
+}
+func f() time.Duration { return 0 }
+`)
+	require.NoError(t, err)
+	assert.Contains(t, output, `other "example.com/some/pkg"`)
+	assert.NotContains(t, output, `"time"`)
+	assert.Contains(t, output, `x.A = jreader.ReadReadable[other.Thing](r)`)
+	assert.Contains(t, output, `jreader.ReadOptional(r, jreader.ReadReadable[other.Thing])`)
+}
+
+func TestUnsupportedTypes(t *testing.T) {
+	for _, fieldType := range []string{
+		"[3]int",
+		"[]byte",
+		"map[int]string",
+		"interface{}",
+		"func()",
+		"chan int",
+		"unknownType",
+		"unknownPackage.Type",
+		"struct{ A int }",
+		"*[]byte",
+	} {
+		t.Run(fieldType, func(t *testing.T) {
+			_, err := generateFromSource(t, "package p\ntype S struct {\n\tA "+fieldType+"\n}\n")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "S.A: ")
+		})
+	}
+
+	t.Run("string option on string", func(t *testing.T) {
+		_, err := generateFromSource(t, "package p\ntype S struct {\n\tA string `json:\",string\"`\n}\n")
+		assert.Error(t, err)
+	})
+
+	t.Run("embedded field", func(t *testing.T) {
+		_, err := generateFromSource(t, "package p\ntype T struct{}\ntype S struct {\n\tT\n}\n", "S")
+		assert.Error(t, err)
+	})
+
+	t.Run("generic struct", func(t *testing.T) {
+		_, err := generateFromSource(t, "package p\ntype S[T any] struct {\n\tA T\n}\n", "S")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,93 @@
+// Package example contains types that are used to test the output of jsonstream-gen. The file
+// jsonstream_gen.go is generated from this file; run "go generate" after changing it.
+package example
+
+//go:generate go run ../.. -type Flag,Variation,Target,Empty,Limits
+
+import (
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+// Kind is a named type with a basic underlying type.
+type Kind string
+
+// Flag exercises most of the field types and tag options that jsonstream-gen supports.
+type Flag struct {
+	Key         string               `json:"key" jsonstream:"required"`
+	Version     int                  `json:"version" jsonstream:"required"`
+	On          bool                 `json:"on"`
+	Kind        Kind                 `json:"kind,omitempty"`
+	Weight      float64              `json:"weight,omitempty"`
+	Small       int8                 `json:"small"`
+	Big         uint64               `json:"big"`
+	Ratio       float32              `json:"ratio"`
+	Scale       float32              `json:"scale,string,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Counts      map[string]int       `json:"counts"`
+	KindCounts  map[Kind]int         `json:"kindCounts,omitempty"`
+	Variations  []Variation          `json:"variations"`
+	Targets     map[string][]*Target `json:"targets,omitempty"`
+	Description *string              `json:"description"`
+	Fallback    *Variation           `json:"fallback,omitempty"`
+	OffKind     *Kind                `json:"offKind"`
+	Timestamp   int64                `json:"timestamp,string"`
+	Enabled     bool                 `json:"enabled,string,omitempty"`
+	Ignored     string               `json:"-"`
+	Untagged    string
+	Position    Position `json:"position"`
+
+	internal int //nolint:unused
+}
+
+// Variation is a nested struct type.
+type Variation struct {
+	Value  string   `json:"value"`
+	Weight *float64 `json:"weight"`
+}
+
+// Target is a nested struct type that is referenced by pointer.
+type Target struct {
+	Values []string `json:"values" jsonstream:"required"`
+}
+
+// Empty is a struct with no properties.
+type Empty struct{}
+
+// Limits has fields of every integer type, which are range-checked when they are read.
+type Limits struct {
+	Int8           int8    `json:"int8"`
+	Int16          int16   `json:"int16"`
+	Int32          int32   `json:"int32"`
+	Int64          int64   `json:"int64"`
+	Uint8          uint8   `json:"uint8"`
+	Uint16         uint16  `json:"uint16"`
+	Uint32         uint32  `json:"uint32"`
+	Uint           uint    `json:"uint"`
+	Uint64         uint64  `json:"uint64"`
+	Optional       *uint8  `json:"optional"`
+	Values         []int16 `json:"values,omitempty"`
+	Quoted         int8    `json:"quoted,string"`
+	QuotedUnsigned uint32  `json:"quotedUnsigned,string"`
+}
+
+// Position is not generated; it has hand-written methods, like a type from another package would.
+type Position struct {
+	X, Y int
+}
+
+// WriteToJSONWriter writes the Position as a JSON array of two numbers.
+func (p Position) WriteToJSONWriter(w *jwriter.Writer) {
+	arr := w.Array()
+	arr.Int(p.X)
+	arr.Int(p.Y)
+	arr.End()
+}
+
+// ReadFromJSONReader reads the Position from a JSON array of two numbers.
+func (p *Position) ReadFromJSONReader(r *jreader.Reader) {
+	values := jreader.ReadSlice(r, (*jreader.Reader).Int)
+	if len(values) == 2 {
+		p.X, p.Y = values[0], values[1]
+	}
+}
@@ -0,0 +1,187 @@
+package example
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	description := "desc"
+	weight := 0.5
+	offKind := Kind("off")
+	flag := Flag{
+		Key:         "flagkey",
+		Version:     2,
+		On:          true,
+		Kind:        "boolean",
+		Weight:      1.5,
+		Small:       -3,
+		Big:         1 << 40,
+		Ratio:       0.1,
+		Scale:       0.3,
+		Tags:        []string{"a", "b"},
+		Counts:      map[string]int{"x": 1, "y": 2},
+		KindCounts:  map[Kind]int{"boolean": 3},
+		Variations:  []Variation{{Value: "v1", Weight: &weight}, {Value: "v2"}},
+		Targets:     map[string][]*Target{"t": {{Values: []string{"u1"}}, nil}},
+		Description: &description,
+		Fallback:    &Variation{Value: "fb"},
+		OffKind:     &offKind,
+		Timestamp:   1603312301195,
+		Enabled:     true,
+		Ignored:     "not written",
+		Untagged:    "u",
+		Position:    Position{X: 1, Y: 2},
+	}
+
+	data, err := jwriter.MarshalJSONWithWriter(flag)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"key": "flagkey", "version": 2, "on": true, "kind": "boolean", "weight": 1.5,
+		"small": -3, "big": 1099511627776, "ratio": 0.1, "scale": "0.3", "tags": ["a", "b"],
+		"counts": {"x": 1, "y": 2}, "kindCounts": {"boolean": 3},
+		"variations": [{"value": "v1", "weight": 0.5}, {"value": "v2", "weight": null}],
+		"targets": {"t": [{"values": ["u1"]}, null]}, "description": "desc", "fallback": {"value": "fb", "weight": null},
+		"offKind": "off", "timestamp": "1603312301195", "enabled": "true", "Untagged": "u", "position": [1, 2]
+	}`, string(data))
+
+	var flag2 Flag
+	require.NoError(t, jreader.UnmarshalJSONWithReader(data, &flag2))
+	flag.Ignored = ""
+	assert.Equal(t, flag, flag2)
+}
+
+func TestOmitEmptyAndNulls(t *testing.T) {
+	flag := Flag{Key: "flagkey"}
+	data, err := jwriter.MarshalJSONWithWriter(flag)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"key": "flagkey", "version": 0, "on": false, "small": 0, "big": 0, "ratio": 0, "counts": null,
+		"variations": null, "description": null, "offKind": null, "timestamp": "0", "Untagged": "",
+		"position": [0, 0]
+	}`, string(data))
+
+	var flag2 Flag
+	require.NoError(t, jreader.UnmarshalJSONWithReader(data, &flag2))
+	assert.Equal(t, flag, flag2)
+}
+
+func TestGeneratedCodeIsCompatibleWithEncodingJSON(t *testing.T) {
+	// encoding/json should interpret the output the same way, apart from the Position type which
+	// has custom methods that encoding/json doesn't know about
+	description := "desc"
+	flag := Flag{Key: "flagkey", Version: 1, Tags: []string{"a"}, Description: &description, Timestamp: 99}
+	data, err := jwriter.MarshalJSONWithWriter(flag)
+	require.NoError(t, err)
+
+	var fromJSON struct {
+		Flag
+		Position []int `json:"position"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fromJSON))
+	assert.Equal(t, flag.Key, fromJSON.Key)
+	assert.Equal(t, flag.Tags, fromJSON.Tags)
+	assert.Equal(t, flag.Description, fromJSON.Description)
+	assert.Equal(t, flag.Timestamp, fromJSON.Timestamp)
+}
+
+func TestRequiredProperties(t *testing.T) {
+	var flag Flag
+	err := jreader.UnmarshalJSONWithReader([]byte(`{"key": "flagkey"}`), &flag)
+	require.IsType(t, jreader.RequiredPropertyError{}, err)
+	assert.Equal(t, "version", err.(jreader.RequiredPropertyError).Name)
+
+	var target Target
+	err = jreader.UnmarshalJSONWithReader([]byte(`{}`), &target)
+	require.IsType(t, jreader.RequiredPropertyError{}, err)
+	assert.Equal(t, "values", err.(jreader.RequiredPropertyError).Name)
+}
+
+func TestStringOptionParseError(t *testing.T) {
+	var flag Flag
+	err := jreader.UnmarshalJSONWithReader([]byte(`{"key": "k", "version": 1, "timestamp": "x"}`), &flag)
+	assert.Error(t, err)
+
+	err = jreader.UnmarshalJSONWithReader([]byte(`{"key": "k", "version": 1, "timestamp": 3}`), &flag)
+	assert.IsType(t, &json.UnmarshalTypeError{}, err)
+}
+
+func TestEmptyStruct(t *testing.T) {
+	data, err := jwriter.MarshalJSONWithWriter(Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(data))
+
+	var e Empty
+	assert.NoError(t, jreader.UnmarshalJSONWithReader([]byte(`{"a": [1, 2]}`), &e))
+	assert.Error(t, jreader.UnmarshalJSONWithReader([]byte(`[]`), &e))
+}
+
+func TestIntegerLimits(t *testing.T) {
+	optional := uint8(math.MaxUint8)
+	for _, limits := range []Limits{
+		{
+			Int8: math.MinInt8, Int16: math.MinInt16, Int32: math.MinInt32, Int64: math.MinInt64,
+			Values: []int16{math.MinInt16}, Quoted: math.MinInt8,
+		},
+		{
+			Int8: math.MaxInt8, Int16: math.MaxInt16, Int32: math.MaxInt32, Int64: math.MaxInt64,
+			Uint8: math.MaxUint8, Uint16: math.MaxUint16, Uint32: math.MaxUint32, Uint: math.MaxUint,
+			Uint64: math.MaxUint64, Optional: &optional, Values: []int16{math.MaxInt16},
+			Quoted: math.MaxInt8, QuotedUnsigned: math.MaxUint32,
+		},
+	} {
+		data, err := jwriter.MarshalJSONWithWriter(limits)
+		require.NoError(t, err)
+		expected, err := json.Marshal(limits)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(data))
+
+		var limits2 Limits
+		require.NoError(t, jreader.UnmarshalJSONWithReader(data, &limits2))
+		assert.Equal(t, limits, limits2)
+	}
+}
+
+func TestIntegerOutOfRange(t *testing.T) {
+	for _, input := range []string{
+		`{"int8": 128}`,
+		`{"int8": -129}`,
+		`{"int16": 32768}`,
+		`{"int32": -2147483649}`,
+		`{"int64": 9223372036854775808}`,
+		`{"uint8": 256}`,
+		`{"uint8": -1}`,
+		`{"uint16": 65536}`,
+		`{"uint32": 4294967296}`,
+		`{"uint": -1}`,
+		`{"uint64": 18446744073709551616}`,
+		`{"uint64": 1.5}`,
+		`{"optional": 256}`,
+		`{"values": [1, 32768]}`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			var limits Limits
+			err := jreader.UnmarshalJSONWithReader([]byte(input), &limits)
+			assert.IsType(t, &json.UnmarshalTypeError{}, err)
+		})
+	}
+
+	for _, input := range []string{
+		`{"quoted": "128"}`,
+		`{"quotedUnsigned": "-1"}`,
+		`{"quotedUnsigned": "4294967296"}`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			var limits Limits
+			err := jreader.UnmarshalJSONWithReader([]byte(input), &limits)
+			assert.Error(t, err)
+		})
+	}
+}
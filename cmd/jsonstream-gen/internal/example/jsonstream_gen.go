@@ -0,0 +1,279 @@
+// Code generated by jsonstream-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"strconv"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+var jsonstreamRequiredPropsFlag = []string{"key", "version"}
+
+// WriteToJSONWriter writes the Flag as a JSON object. It implements jwriter.Writable.
+func (x Flag) WriteToJSONWriter(w *jwriter.Writer) {
+	obj := w.Object()
+	obj.Name("key").String(x.Key)
+	obj.Name("version").Int(x.Version)
+	obj.Name("on").Bool(x.On)
+	obj.Maybe("kind", x.Kind != "").String(string(x.Kind))
+	obj.Maybe("weight", x.Weight != 0).Float64(x.Weight)
+	obj.Name("small").Int(int(x.Small))
+	obj.Name("big").Uint64(x.Big)
+	obj.Name("ratio").Float32(x.Ratio)
+	obj.Maybe("scale", x.Scale != 0).String(strconv.FormatFloat(float64(x.Scale), 'g', -1, 32))
+	jwriter.WriteSlice(obj.Maybe("tags", len(x.Tags) != 0), x.Tags, (*jwriter.Writer).String)
+	jwriter.WriteMap(obj.Name("counts"), x.Counts, (*jwriter.Writer).Int)
+	jwriter.WriteMap(obj.Maybe("kindCounts", len(x.KindCounts) != 0), x.KindCounts, (*jwriter.Writer).Int)
+	jwriter.WriteSlice(obj.Name("variations"), x.Variations, jwriter.WriteWritable[Variation])
+	jwriter.WriteMap(obj.Maybe("targets", len(x.Targets) != 0), x.Targets, func(w *jwriter.Writer, v []*Target) {
+		jwriter.WriteSlice(w, v, func(w *jwriter.Writer, v *Target) {
+			if v == nil {
+				w.Null()
+			} else {
+				v.WriteToJSONWriter(w)
+			}
+		})
+	})
+	if x.Description == nil {
+		obj.Name("description").Null()
+	} else {
+		obj.Name("description").String(*x.Description)
+	}
+	if x.Fallback != nil {
+		x.Fallback.WriteToJSONWriter(obj.Name("fallback"))
+	}
+	if x.OffKind == nil {
+		obj.Name("offKind").Null()
+	} else {
+		obj.Name("offKind").String(string(*x.OffKind))
+	}
+	obj.Name("timestamp").String(strconv.FormatInt(x.Timestamp, 10))
+	obj.Maybe("enabled", x.Enabled).String(strconv.FormatBool(x.Enabled))
+	obj.Name("Untagged").String(x.Untagged)
+	x.Position.WriteToJSONWriter(obj.Name("position"))
+	obj.End()
+}
+
+// ReadFromJSONReader reads the Flag from a JSON object. It implements jreader.Readable.
+func (x *Flag) ReadFromJSONReader(r *jreader.Reader) {
+	for obj := r.Object().WithRequiredProperties(jsonstreamRequiredPropsFlag); obj.Next(); {
+		switch string(obj.Name()) {
+		case "key":
+			x.Key = r.String()
+		case "version":
+			x.Version = jreader.ReadInteger[int](r)
+		case "on":
+			x.On = r.Bool()
+		case "kind":
+			x.Kind = Kind(r.String())
+		case "weight":
+			x.Weight = r.Float64()
+		case "small":
+			x.Small = jreader.ReadInteger[int8](r)
+		case "big":
+			x.Big = jreader.ReadInteger[uint64](r)
+		case "ratio":
+			x.Ratio = r.Float32()
+		case "scale":
+			if v, err := strconv.ParseFloat(r.String(), 32); err != nil {
+				r.AddError(err)
+			} else {
+				x.Scale = float32(v)
+			}
+		case "tags":
+			x.Tags = jreader.ReadSlice(r, (*jreader.Reader).String)
+		case "counts":
+			x.Counts = jreader.ReadMap(r, jreader.ReadInteger[int])
+		case "kindCounts":
+			x.KindCounts = func(r *jreader.Reader) map[Kind]int {
+				m := jreader.ReadMap(r, jreader.ReadInteger[int])
+				if m == nil {
+					return nil
+				}
+				ret := make(map[Kind]int, len(m))
+				for k, v := range m {
+					ret[Kind(k)] = v
+				}
+				return ret
+			}(r)
+		case "variations":
+			x.Variations = jreader.ReadSlice(r, jreader.ReadReadable[Variation])
+		case "targets":
+			x.Targets = jreader.ReadMap(r, func(r *jreader.Reader) []*Target {
+				return jreader.ReadSlice(r, func(r *jreader.Reader) *Target {
+					if v, ok := jreader.ReadOptional(r, jreader.ReadReadable[Target]); ok {
+						return &v
+					}
+					return nil
+				})
+			})
+		case "description":
+			if v, ok := r.StringOrNull(); ok {
+				x.Description = &v
+			} else {
+				x.Description = nil
+			}
+		case "fallback":
+			if v, ok := jreader.ReadOptional(r, jreader.ReadReadable[Variation]); ok {
+				x.Fallback = &v
+			} else {
+				x.Fallback = nil
+			}
+		case "offKind":
+			if v, ok := r.StringOrNull(); ok {
+				value := Kind(v)
+				x.OffKind = &value
+			} else {
+				x.OffKind = nil
+			}
+		case "timestamp":
+			if v, err := strconv.ParseInt(r.String(), 10, 64); err != nil {
+				r.AddError(err)
+			} else {
+				x.Timestamp = v
+			}
+		case "enabled":
+			if v, err := strconv.ParseBool(r.String()); err != nil {
+				r.AddError(err)
+			} else {
+				x.Enabled = v
+			}
+		case "Untagged":
+			x.Untagged = r.String()
+		case "position":
+			x.Position = jreader.ReadReadable[Position](r)
+		}
+	}
+}
+
+// WriteToJSONWriter writes the Variation as a JSON object. It implements jwriter.Writable.
+func (x Variation) WriteToJSONWriter(w *jwriter.Writer) {
+	obj := w.Object()
+	obj.Name("value").String(x.Value)
+	if x.Weight == nil {
+		obj.Name("weight").Null()
+	} else {
+		obj.Name("weight").Float64(*x.Weight)
+	}
+	obj.End()
+}
+
+// ReadFromJSONReader reads the Variation from a JSON object. It implements jreader.Readable.
+func (x *Variation) ReadFromJSONReader(r *jreader.Reader) {
+	for obj := r.Object(); obj.Next(); {
+		switch string(obj.Name()) {
+		case "value":
+			x.Value = r.String()
+		case "weight":
+			if v, ok := r.Float64OrNull(); ok {
+				x.Weight = &v
+			} else {
+				x.Weight = nil
+			}
+		}
+	}
+}
+
+var jsonstreamRequiredPropsTarget = []string{"values"}
+
+// WriteToJSONWriter writes the Target as a JSON object. It implements jwriter.Writable.
+func (x Target) WriteToJSONWriter(w *jwriter.Writer) {
+	obj := w.Object()
+	jwriter.WriteSlice(obj.Name("values"), x.Values, (*jwriter.Writer).String)
+	obj.End()
+}
+
+// ReadFromJSONReader reads the Target from a JSON object. It implements jreader.Readable.
+func (x *Target) ReadFromJSONReader(r *jreader.Reader) {
+	for obj := r.Object().WithRequiredProperties(jsonstreamRequiredPropsTarget); obj.Next(); {
+		switch string(obj.Name()) {
+		case "values":
+			x.Values = jreader.ReadSlice(r, (*jreader.Reader).String)
+		}
+	}
+}
+
+// WriteToJSONWriter writes the Empty as a JSON object. It implements jwriter.Writable.
+func (x Empty) WriteToJSONWriter(w *jwriter.Writer) {
+	obj := w.Object()
+	obj.End()
+}
+
+// ReadFromJSONReader reads the Empty from a JSON object. It implements jreader.Readable.
+func (x *Empty) ReadFromJSONReader(r *jreader.Reader) {
+	for obj := r.Object(); obj.Next(); {
+	}
+}
+
+// WriteToJSONWriter writes the Limits as a JSON object. It implements jwriter.Writable.
+func (x Limits) WriteToJSONWriter(w *jwriter.Writer) {
+	obj := w.Object()
+	obj.Name("int8").Int(int(x.Int8))
+	obj.Name("int16").Int(int(x.Int16))
+	obj.Name("int32").Int(int(x.Int32))
+	obj.Name("int64").Int64(x.Int64)
+	obj.Name("uint8").Int(int(x.Uint8))
+	obj.Name("uint16").Int(int(x.Uint16))
+	obj.Name("uint32").Uint64(uint64(x.Uint32))
+	obj.Name("uint").Uint64(uint64(x.Uint))
+	obj.Name("uint64").Uint64(x.Uint64)
+	if x.Optional == nil {
+		obj.Name("optional").Null()
+	} else {
+		obj.Name("optional").Int(int(*x.Optional))
+	}
+	jwriter.WriteSlice(obj.Maybe("values", len(x.Values) != 0), x.Values, func(w *jwriter.Writer, v int16) {
+		w.Int(int(v))
+	})
+	obj.Name("quoted").String(strconv.FormatInt(int64(x.Quoted), 10))
+	obj.Name("quotedUnsigned").String(strconv.FormatUint(uint64(x.QuotedUnsigned), 10))
+	obj.End()
+}
+
+// ReadFromJSONReader reads the Limits from a JSON object. It implements jreader.Readable.
+func (x *Limits) ReadFromJSONReader(r *jreader.Reader) {
+	for obj := r.Object(); obj.Next(); {
+		switch string(obj.Name()) {
+		case "int8":
+			x.Int8 = jreader.ReadInteger[int8](r)
+		case "int16":
+			x.Int16 = jreader.ReadInteger[int16](r)
+		case "int32":
+			x.Int32 = jreader.ReadInteger[int32](r)
+		case "int64":
+			x.Int64 = jreader.ReadInteger[int64](r)
+		case "uint8":
+			x.Uint8 = jreader.ReadInteger[uint8](r)
+		case "uint16":
+			x.Uint16 = jreader.ReadInteger[uint16](r)
+		case "uint32":
+			x.Uint32 = jreader.ReadInteger[uint32](r)
+		case "uint":
+			x.Uint = jreader.ReadInteger[uint](r)
+		case "uint64":
+			x.Uint64 = jreader.ReadInteger[uint64](r)
+		case "optional":
+			if v, ok := jreader.ReadOptional(r, jreader.ReadInteger[uint8]); ok {
+				x.Optional = &v
+			} else {
+				x.Optional = nil
+			}
+		case "values":
+			x.Values = jreader.ReadSlice(r, jreader.ReadInteger[int16])
+		case "quoted":
+			if v, err := strconv.ParseInt(r.String(), 10, 8); err != nil {
+				r.AddError(err)
+			} else {
+				x.Quoted = int8(v)
+			}
+		case "quotedUnsigned":
+			if v, err := strconv.ParseUint(r.String(), 10, 32); err != nil {
+				r.AddError(err)
+			} else {
+				x.QuotedUnsigned = uint32(v)
+			}
+		}
+	}
+}
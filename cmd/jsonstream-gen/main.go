@@ -0,0 +1,91 @@
+// Command jsonstream-gen generates implementations of jreader.Readable and jwriter.Writable for Go
+// struct types, so that they can be read and written with go-jsonstream without hand-written code.
+//
+// Usage:
+//
+//	jsonstream-gen [-type T1,T2,...] [-output file] [directory]
+//
+// It reads the Go source files in the specified directory (or the current directory), and for
+// each of the specified struct types (or all struct types in the package, if -type is omitted) it
+// generates a WriteToJSONWriter method and a ReadFromJSONReader method. The output file defaults
+// to jsonstream_gen.go in the same directory. It is typically invoked with a go:generate comment:
+//
+//	//go:generate go run github.com/launchdarkly/go-jsonstream/v3/cmd/jsonstream-gen -type MyType
+//
+// Struct fields are mapped to JSON properties using the same "json" struct tags as encoding/json:
+// the property name can be changed, a field can be excluded with "-", and the "omitempty" and
+// "string" options are supported. Unexported fields and fields tagged with "-" are ignored;
+// embedded fields are not supported. In addition, a field can be tagged with
+// `jsonstream:"required"` to make the generated code fail with a jreader.RequiredPropertyError if
+// the property is missing, using ObjectState.WithRequiredProperties.
+//
+// The supported field types are bool, string, and the numeric types; named types whose underlying
+// type is one of those; slices and string-keyed maps of any supported type; pointers to any
+// supported type, which are written as null if they are nil and read as nil from a null; and named
+// struct types. Any other named type, including all types from other packages, is assumed to
+// implement jreader.Readable (with a pointer receiver) and jwriter.Writable (with a value receiver).
+//
+// Integer fields are written without loss of precision, and are read with jreader.ReadInteger, so
+// a number that is out of range for the field's type causes a jreader.RangeError rather than being
+// truncated or wrapped around.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultOutputFileName = "jsonstream_gen.go"
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of type names (default: all struct types)")
+	output := flag.String("output", "", "output file name (default: "+defaultOutputFileName+" in the package directory)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: jsonstream-gen [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	if err := run(dir, *output, *typeNames); err != nil {
+		fmt.Fprintln(os.Stderr, "jsonstream-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, outputFile, typeNames string) error {
+	if outputFile == "" {
+		outputFile = filepath.Join(dir, defaultOutputFileName)
+	}
+	var names []string
+	if typeNames != "" {
+		for _, name := range strings.Split(typeNames, ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+	pkg, err := loadPackage(dir, outputFile)
+	if err != nil {
+		return err
+	}
+	structs, err := pkg.selectStructs(names)
+	if err != nil {
+		return err
+	}
+	if len(structs) == 0 {
+		return fmt.Errorf("no struct types found in %s", dir)
+	}
+	source, err := generate(pkg, structs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputFile, source, 0644) //nolint:gosec // generated source files are not secret
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// requiredTagName is the struct tag key that marks a property as required, in addition to the
+// standard "json" key: `json:"key" jsonstream:"required"`.
+const requiredTagName = "jsonstream"
+
+// packageInfo describes the parts of a Go package that the generator needs to know about.
+type packageInfo struct {
+	name string
+
+	// structs are the struct type declarations in the package, in source order.
+	structs []*ast.TypeSpec
+
+	// localTypes contains all type declarations in the package, so that named types can be
+	// resolved to their underlying types.
+	localTypes map[string]*ast.TypeSpec
+
+	// imports maps the local name of each imported package to its import path, for any package
+	// that is referenced by a struct field type.
+	imports map[string]string
+}
+
+// structInfo describes one struct type for which code will be generated.
+type structInfo struct {
+	name   string
+	fields []fieldInfo
+}
+
+// fieldInfo describes one struct field that corresponds to a JSON property.
+type fieldInfo struct {
+	goName    string
+	jsonName  string
+	typ       ast.Expr
+	omitEmpty bool
+	asString  bool
+	required  bool
+}
+
+// loadPackage parses all of the non-test Go files in a directory, except for the file that we are
+// about to generate.
+func loadPackage(dir, outputFile string) (*packageInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	outputPath, _ := filepath.Abs(outputFile)
+	fset := token.NewFileSet()
+	var files []*ast.File
+	pkgName := ""
+	for _, entry := range entries { // os.ReadDir returns entries sorted by name
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if absPath, _ := filepath.Abs(path); absPath == outputPath {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if pkgName != "" && file.Name.Name != pkgName {
+			return nil, fmt.Errorf("found more than one Go package in %s (%s, %s)", dir, pkgName, file.Name.Name)
+		}
+		pkgName = file.Name.Name
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go source files found in %s", dir)
+	}
+	return parsePackage(pkgName, files), nil
+}
+
+func parsePackage(name string, files []*ast.File) *packageInfo {
+	info := &packageInfo{
+		name:       name,
+		localTypes: make(map[string]*ast.TypeSpec),
+		imports:    make(map[string]string),
+	}
+	for _, file := range files {
+		fileImports := make(map[string]string)
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			localName := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				localName = spec.Name.Name
+			}
+			fileImports[localName] = path
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				info.localTypes[typeSpec.Name.Name] = typeSpec
+				if _, ok := typeSpec.Type.(*ast.StructType); ok && typeSpec.TypeParams == nil {
+					info.structs = append(info.structs, typeSpec)
+				}
+			}
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if pkgIdent, ok := sel.X.(*ast.Ident); ok {
+					if path, ok := fileImports[pkgIdent.Name]; ok {
+						info.imports[pkgIdent.Name] = path
+					}
+				}
+			}
+			return true
+		})
+	}
+	return info
+}
+
+// selectStructs returns the structs whose names were requested, or all of them if none were.
+func (p *packageInfo) selectStructs(typeNames []string) ([]structInfo, error) {
+	var specs []*ast.TypeSpec
+	if len(typeNames) == 0 {
+		specs = p.structs
+	} else {
+		for _, name := range typeNames {
+			spec, ok := p.localTypes[name]
+			if !ok {
+				return nil, fmt.Errorf("type %s not found", name)
+			}
+			if _, isStruct := spec.Type.(*ast.StructType); !isStruct || spec.TypeParams != nil {
+				return nil, fmt.Errorf("type %s is not a non-generic struct type", name)
+			}
+			specs = append(specs, spec)
+		}
+	}
+	ret := make([]structInfo, 0, len(specs))
+	for _, spec := range specs {
+		si, err := parseStruct(spec)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, si)
+	}
+	return ret, nil
+}
+
+func parseStruct(spec *ast.TypeSpec) (structInfo, error) {
+	si := structInfo{name: spec.Name.Name}
+	for _, field := range spec.Type.(*ast.StructType).Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			tagValue, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(tagValue)
+		}
+		jsonTag := tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		if len(field.Names) == 0 {
+			return si, fmt.Errorf("%s: embedded fields are not supported", spec.Name.Name)
+		}
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+			fi := fieldInfo{goName: fieldName.Name, jsonName: fieldName.Name, typ: field.Type}
+			tagParts := strings.Split(jsonTag, ",")
+			if tagParts[0] != "" {
+				fi.jsonName = tagParts[0]
+			}
+			for _, option := range tagParts[1:] {
+				switch option {
+				case "omitempty":
+					fi.omitEmpty = true
+				case "string":
+					fi.asString = true
+				}
+			}
+			for _, option := range strings.Split(tag.Get(requiredTagName), ",") {
+				if option == "required" {
+					fi.required = true
+				}
+			}
+			si.fields = append(si.fields, fi)
+		}
+	}
+	return si, nil
+}
@@ -0,0 +1,29 @@
+// Package ordered defines the representation of a JSON object that preserves the order of its
+// properties. It is exposed as jreader.OrderedObject, which is produced by jreader and consumed by
+// jwriter; it is defined here so that jwriter does not need to import jreader.
+package ordered
+
+// Object is a representation of a JSON object that preserves the order of its properties. It is
+// returned by jreader.Reader.ReadInterfaceWithOptions if OrderedObjects is true, and is written as a
+// JSON object by jwriter.Writer.Value.
+type Object []Property
+
+// Property is a single property in an Object.
+type Property struct {
+	// Name is the property name.
+	Name string
+
+	// Value is the property value.
+	Value interface{}
+}
+
+// Get returns the value of the property with the specified name, and true if it was found. If the
+// name appears more than once, the last value wins, as it would in a map.
+func (o Object) Get(name string) (interface{}, bool) {
+	for i := len(o) - 1; i >= 0; i-- {
+		if o[i].Name == name {
+			return o[i].Value, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,228 @@
+// Package structinfo provides reflection metadata about Go struct types for the reflection-based
+// reading and writing functions in jreader and jwriter.
+//
+// The rules for mapping struct fields to JSON properties are the same as in encoding/json: the
+// "json" struct tag can specify a property name, "-" to exclude the field, and the options
+// "omitempty" and "string". Fields of embedded structs are promoted into the outer struct, with
+// shallower fields hiding deeper ones. In addition, the tag `jsonstream:"required"` marks a
+// property as required when reading.
+package structinfo
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Field describes a struct field that corresponds to a JSON property.
+type Field struct {
+	// Name is the JSON property name.
+	Name string
+
+	// Index is the sequence of field indexes for reflect.Value.Field, starting from the outer
+	// struct, that leads to this field. It has more than one element if the field was promoted
+	// from an embedded struct.
+	Index []int
+
+	// Type is the Go type of the field.
+	Type reflect.Type
+
+	// OmitEmpty is true if the "omitempty" option was specified.
+	OmitEmpty bool
+
+	// AsString is true if the "string" option was specified, and the field's type is one that the
+	// option applies to (a boolean or numeric type).
+	AsString bool
+
+	// Required is true if the field was tagged with `jsonstream:"required"`.
+	Required bool
+
+	nameBytes []byte // the same as Name, for case-insensitive comparisons without allocating
+	tagged    bool
+}
+
+// Struct describes the JSON properties of a struct type.
+type Struct struct {
+	// Fields contains all of the fields that correspond to JSON properties, in field order.
+	Fields []Field
+
+	// RequiredNames contains the names of all required properties, if any.
+	RequiredNames []string
+
+	byName map[string]int
+}
+
+var cache sync.Map //nolint:gochecknoglobals
+
+// ForType returns the metadata for a struct type. The result is computed once per type and cached.
+func ForType(t reflect.Type) *Struct {
+	if s, ok := cache.Load(t); ok {
+		return s.(*Struct)
+	}
+	s, _ := cache.LoadOrStore(t, computeStruct(t))
+	return s.(*Struct)
+}
+
+// Lookup finds the field for a JSON property name. As in encoding/json, an exact match is
+// preferred, but a case-insensitive match is also accepted. It returns nil if there is no match.
+func (s *Struct) Lookup(name []byte) *Field {
+	if i, ok := s.byName[string(name)]; ok { // the compiler optimizes this to avoid a string allocation
+		return &s.Fields[i]
+	}
+	for i := range s.Fields {
+		if bytes.EqualFold(s.Fields[i].nameBytes, name) {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+type queuedStruct struct {
+	typ   reflect.Type
+	index []int
+}
+
+func computeStruct(t reflect.Type) *Struct {
+	var fields []Field
+	found := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	next := []queuedStruct{{typ: t}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		var candidates []Field
+		for _, q := range current {
+			if visited[q.typ] {
+				continue
+			}
+			visited[q.typ] = true
+			for i := 0; i < q.typ.NumField(); i++ {
+				sf := q.typ.Field(i)
+				fieldType := sf.Type
+				if sf.Anonymous && fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+				if !sf.IsExported() && !(sf.Anonymous && fieldType.Kind() == reflect.Struct) {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, options := tag, ""
+				if comma := strings.IndexByte(tag, ','); comma >= 0 {
+					name, options = tag[:comma], tag[comma:]
+				}
+				index := make([]int, len(q.index)+1)
+				copy(index, q.index)
+				index[len(q.index)] = i
+				if name == "" && sf.Anonymous && fieldType.Kind() == reflect.Struct {
+					next = append(next, queuedStruct{typ: fieldType, index: index})
+					continue
+				}
+				if !sf.IsExported() {
+					continue // an unexported embedded struct with an explicit name is not promoted
+				}
+				f := Field{Name: name, Index: index, Type: sf.Type, tagged: name != ""}
+				if name == "" {
+					f.Name = sf.Name
+				}
+				f.OmitEmpty = strings.Contains(options+",", ",omitempty,")
+				f.AsString = strings.Contains(options+",", ",string,") && isStringOptionKind(sf.Type.Kind())
+				f.Required = strings.Contains(","+sf.Tag.Get("jsonstream")+",", ",required,")
+				candidates = append(candidates, f)
+			}
+		}
+		fields = append(fields, dominantFields(candidates, found)...)
+	}
+	sort.Slice(fields, func(i, j int) bool { return lessIndex(fields[i].Index, fields[j].Index) })
+
+	s := &Struct{Fields: fields, byName: make(map[string]int, len(fields))}
+	for i, f := range fields {
+		fields[i].nameBytes = []byte(f.Name)
+		s.byName[f.Name] = i
+		if f.Required {
+			s.RequiredNames = append(s.RequiredNames, f.Name)
+		}
+	}
+	return s
+}
+
+// dominantFields applies the encoding/json rules for resolving name conflicts among fields at the
+// same embedding depth: a name that was already used at a shallower depth is hidden; otherwise,
+// if exactly one of the conflicting fields has an explicit name in its tag, it wins, and if not,
+// all of them are dropped.
+func dominantFields(candidates []Field, found map[string]bool) []Field {
+	byName := make(map[string][]Field)
+	var names []string
+	for _, f := range candidates {
+		if found[f.Name] {
+			continue
+		}
+		if _, ok := byName[f.Name]; !ok {
+			names = append(names, f.Name)
+		}
+		byName[f.Name] = append(byName[f.Name], f)
+	}
+	var ret []Field
+	for _, name := range names {
+		found[name] = true
+		fs := byName[name]
+		if len(fs) == 1 {
+			ret = append(ret, fs[0])
+			continue
+		}
+		var tagged []Field
+		for _, f := range fs {
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+		if len(tagged) == 1 {
+			ret = append(ret, tagged[0])
+		}
+	}
+	return ret
+}
+
+func isStringOptionKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// IsEmptyValue returns true if a value should be omitted by the "omitempty" option: false, 0, a
+// nil pointer or interface, or an empty string, slice, map, or array.
+func IsEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
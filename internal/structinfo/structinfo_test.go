@@ -0,0 +1,94 @@
+package structinfo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type embeddedA struct {
+	A       string
+	Shared  string
+	Tagged  string `json:"tagged"`
+	Ignored string `json:"-"`
+}
+
+type embeddedB struct {
+	Shared  string
+	Tagged2 string `json:"tagged"`
+}
+
+type outer struct {
+	embeddedA
+	*embeddedB
+	Name      string `json:"name,omitempty" jsonstream:"required"`
+	Count     int    `json:",string"`
+	NotNumber string `json:"notNumber,string"`
+	private   string
+}
+
+func fieldNames(s *Struct) []string {
+	var ret []string
+	for _, f := range s.Fields {
+		ret = append(ret, f.Name)
+	}
+	return ret
+}
+
+func TestForType(t *testing.T) {
+	s := ForType(reflect.TypeOf(outer{}))
+	assert.Same(t, s, ForType(reflect.TypeOf(outer{})))
+
+	// "Shared" is ambiguous and dropped; "tagged" is tagged in both embedded structs and dropped.
+	assert.Equal(t, []string{"A", "name", "Count", "notNumber"}, fieldNames(s))
+	assert.Equal(t, []int{0, 0}, s.Fields[0].Index)
+	assert.Equal(t, []string{"name"}, s.RequiredNames)
+
+	name := s.Lookup([]byte("name"))
+	if assert.NotNil(t, name) {
+		assert.True(t, name.OmitEmpty)
+		assert.True(t, name.Required)
+	}
+	count := s.Lookup([]byte("count"))
+	if assert.NotNil(t, count) {
+		assert.Equal(t, "Count", count.Name)
+		assert.True(t, count.AsString)
+	}
+	assert.False(t, s.Lookup([]byte("notNumber")).AsString)
+	assert.Nil(t, s.Lookup([]byte("private")))
+}
+
+func TestLookupDoesNotAllocate(t *testing.T) {
+	s := ForType(reflect.TypeOf(outer{}))
+	exact, caseInsensitive, missing := []byte("Count"), []byte("COUNT"), []byte("nothing")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = s.Lookup(exact)
+		_ = s.Lookup(caseInsensitive)
+		_ = s.Lookup(missing)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func TestShallowerFieldWins(t *testing.T) {
+	type inner struct {
+		Name  string
+		Other string
+	}
+	type value struct {
+		inner
+		Name int
+	}
+	s := ForType(reflect.TypeOf(value{}))
+	assert.Equal(t, []string{"Other", "Name"}, fieldNames(s))
+	assert.Equal(t, reflect.TypeOf(0), s.Fields[1].Type)
+}
+
+func TestIsEmptyValue(t *testing.T) {
+	for _, v := range []interface{}{false, 0, uint(0), 0.0, "", []int{}, map[string]int{}, (*int)(nil), [0]int{}} {
+		assert.True(t, IsEmptyValue(reflect.ValueOf(v)), "%#v", v)
+	}
+	for _, v := range []interface{}{true, 1, uint(1), 0.5, "x", []int{1}, map[string]int{"a": 1}, new(int), struct{}{}} {
+		assert.False(t, IsEmptyValue(reflect.ValueOf(v)), "%#v", v)
+	}
+}
@@ -0,0 +1,76 @@
+package jhash
+
+import (
+	"hash"
+	"strconv"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+const writableBufferSize = 512
+
+// HashReader reads the next JSON value of any type from the Reader, including all nested values
+// within an array or object, and returns its structural hash computed with hash functions created
+// by newHash.
+//
+// If there is a parsing error, the Reader enters a failed state, which you can detect with Error(),
+// and the return value is nil.
+func HashReader[H hash.Hash](r *jreader.Reader, newHash func() H) []byte {
+	s := newHashState(func() hash.Hash { return newHash() })
+	readValue(r, &s)
+	if r.Error() != nil {
+		return nil
+	}
+	return s.sum()
+}
+
+// HashWritable returns the structural hash of the JSON data that a Writable produces, computed
+// with hash functions created by newHash. This is the same as the result of HashReader for the
+// serialized data, but the data is not serialized first: the Writer's output goes to a Hasher in
+// small pieces, each of which is hashed as soon as it is written.
+//
+// It returns an error if the Writable causes an error in the Writer, or produces malformed JSON
+// (which is only possible with Writer.Raw).
+func HashWritable[H hash.Hash](value jwriter.Writable, newHash func() H) ([]byte, error) {
+	h := New(newHash)
+	w := jwriter.NewStreamingWriter(h, writableBufferSize)
+	value.WriteToJSONWriter(&w)
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return h.Sum()
+}
+
+func readValue(r *jreader.Reader, s *hashState) {
+	value, literal := r.AnyWithNumberLiteral()
+	switch value.Kind {
+	case jreader.BoolValue:
+		s.bool(value.Bool)
+	case jreader.NumberValue:
+		// We parse the literal ourselves, because the Reader's conversion of large integers to
+		// float64 depends on the JSON backend. The Reader has already rejected out-of-range values.
+		n, _ := strconv.ParseFloat(string(literal), 64)
+		s.number(n)
+	case jreader.StringValue:
+		s.string([]byte(value.String))
+	case jreader.ArrayValue:
+		s.beginArray()
+		for value.Array.Next() {
+			readValue(r, s)
+		}
+		s.endArray()
+	case jreader.ObjectValue:
+		s.beginObject()
+		for value.Object.Next() {
+			s.name(value.Object.Name())
+			readValue(r, s)
+		}
+		s.endObject()
+	default:
+		s.null()
+	}
+}
@@ -0,0 +1,47 @@
+package jhash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+)
+
+func ExampleHashReader() {
+	r1 := jreader.NewReader([]byte(`{"key": "x", "values": [1, 2]}`))
+	r2 := jreader.NewReader([]byte(`{"values":[1.0,2.0],"key":"x"}`))
+	sum1 := HashReader(&r1, sha256.New)
+	sum2 := HashReader(&r2, sha256.New)
+	fmt.Println(bytes.Equal(sum1, sum2))
+	// Output: true
+}
+
+func ExampleHashWritable() {
+	v := jvalue.NewObjectBuilder().
+		Set("key", jvalue.String("x")).
+		Set("values", jvalue.ArrayOf(jvalue.Int(1), jvalue.Int(2))).
+		Build()
+	sum1, _ := HashWritable(v, fnv.New64a)
+
+	r := jreader.NewReader([]byte(`{"values": [1, 2], "key": "x"}`))
+	sum2 := HashReader(&r, fnv.New64a)
+	fmt.Println(bytes.Equal(sum1, sum2))
+	// Output: true
+}
+
+func ExampleHasher() {
+	h := New(sha256.New)
+	_, _ = h.Write([]byte(`{"key": "x", "val`))
+	_, _ = h.Write([]byte(`ues": [1, 2]}`))
+	sum1, err := h.Sum()
+	fmt.Println(err)
+
+	r := jreader.NewReader([]byte(`{"values":[1,2],"key":"x"}`))
+	sum2 := HashReader(&r, sha256.New)
+	fmt.Println(bytes.Equal(sum1, sum2))
+	// Output: <nil>
+	// true
+}
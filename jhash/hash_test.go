@@ -0,0 +1,246 @@
+package jhash
+
+import (
+	"crypto/sha256"
+	"hash/fnv"
+	"strings"
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Each of these groups contains encodings of the same data, which should all have the same hash;
+// values in different groups should have different hashes.
+var hashEquivalenceGroups = [][]string{ //nolint:gochecknoglobals
+	{`null`, ` null `},
+	{`true`},
+	{`false`},
+	{`0`, `-0`, `0.0`, `0e5`},
+	{`1`, `1.0`, `1e0`, `10E-1`, ` 1 `},
+	{`-1.5`, `-15e-1`},
+	{`18446744073709551616`, `18446744073709551617`, `1.8446744073709551616e19`}, // 2^64 as a float64
+	{`-9223372036854775809`, `-9.223372036854775808e18`},
+	{`""`},
+	{`"0"`},
+	{`"null"`},
+	{`"a"`, `"a"`},
+	{`"é\n/"`, `"é\n\/"`},
+	{`"😀"`, `"😀"`},
+	{`[]`, `[ ]`},
+	{`[[]]`},
+	{`[[],[]]`},
+	{`[null]`},
+	{`[1,2]`, `[ 1.0 , 2 ]`},
+	{`[2,1]`},
+	{`[[1],2]`},
+	{`[1,[2]]`},
+	{`{}`, `{ }`},
+	{`{"a":1}`, `{ "a" : 1.0 }`},
+	{`{"a":"1"}`},
+	{`{"b":1}`},
+	{`{"":1}`},
+	{`{"a":1,"b":2}`, `{"b":2,"a":1}`},
+	{`{"a":2,"b":1}`},
+	{`{"a":{"b":[1,{"c":null,"d":true}]},"e":"x"}`, `{"e":"x","a":{"b":[1,{"d":true,"c":null}]}}`},
+	{`{"a":{"b":[{"c":null,"d":true},1]},"e":"x"}`},
+	{`[{"a":1},{"b":2}]`},
+	{`[{"b":2},{"a":1}]`},
+	{`[{"a":1,"b":2}]`},
+	{`{"a":[]}`},
+	{`{"a":{}}`},
+	{`{"ab":"c"}`},
+	{`{"a":"bc"}`},
+	{`["ab","c"]`},
+	{`["a","bc"]`},
+}
+
+type writableFunc func(*jwriter.Writer)
+
+func (f writableFunc) WriteToJSONWriter(w *jwriter.Writer) { f(w) }
+
+func hashWithReader(t *testing.T, input string) []byte {
+	r := jreader.NewReader([]byte(input))
+	sum := HashReader(&r, sha256.New)
+	require.NoError(t, r.Error())
+	require.NoError(t, r.RequireEOF())
+	return sum
+}
+
+func hashWithHasher(t *testing.T, input string) []byte {
+	h := New(sha256.New)
+	for i := 0; i < len(input); i++ { // write one byte at a time to test all the parser states
+		_, err := h.Write([]byte{input[i]})
+		require.NoError(t, err)
+	}
+	sum, err := h.Sum()
+	require.NoError(t, err)
+	return sum
+}
+
+func hashWithWritable(t *testing.T, input string) []byte {
+	v, err := jvalue.Parse([]byte(input))
+	require.NoError(t, err)
+	sum, err := HashWritable(v, sha256.New)
+	require.NoError(t, err)
+	return sum
+}
+
+func TestEquivalentValuesHaveSameHash(t *testing.T) {
+	for _, group := range hashEquivalenceGroups {
+		expected := hashWithReader(t, group[0])
+		for _, input := range group {
+			t.Run(input, func(t *testing.T) {
+				assert.Equal(t, expected, hashWithReader(t, input), "HashReader")
+				assert.Equal(t, expected, hashWithHasher(t, input), "Hasher")
+				assert.Equal(t, expected, hashWithWritable(t, input), "HashWritable")
+			})
+		}
+	}
+}
+
+func TestDifferentValuesHaveDifferentHashes(t *testing.T) {
+	seen := make(map[string]string)
+	for _, group := range hashEquivalenceGroups {
+		sum := string(hashWithReader(t, group[0]))
+		if previous, ok := seen[sum]; ok {
+			assert.Fail(t, "hash collision", "%s and %s", previous, group[0])
+		}
+		seen[sum] = group[0]
+	}
+}
+
+func TestHashWithOtherAlgorithm(t *testing.T) {
+	r := jreader.NewReader([]byte(`{"a":1,"b":[true]}`))
+	sum := HashReader(&r, fnv.New64a)
+	require.NoError(t, r.Error())
+	assert.Len(t, sum, 8)
+
+	r = jreader.NewReader([]byte(`{"b":[true],"a":1}`))
+	assert.Equal(t, sum, HashReader(&r, fnv.New64a))
+}
+
+func TestHashReaderLeavesReaderAfterValue(t *testing.T) {
+	r := jreader.NewReader([]byte(`[{"a":1},{"b":2}]`))
+	arr := r.Array()
+	require.True(t, arr.Next())
+	first := HashReader(&r, sha256.New)
+	require.True(t, arr.Next())
+	second := HashReader(&r, sha256.New)
+	require.False(t, arr.Next())
+	require.NoError(t, r.Error())
+	assert.Equal(t, hashWithReader(t, `{"a":1}`), first)
+	assert.Equal(t, hashWithReader(t, `{"b":2}`), second)
+}
+
+func TestHashReaderError(t *testing.T) {
+	r := jreader.NewReader([]byte(`{"a":[1,`))
+	assert.Nil(t, HashReader(&r, sha256.New))
+	assert.Error(t, r.Error())
+}
+
+func TestHashWritableError(t *testing.T) {
+	_, err := HashWritable(writableFunc(func(w *jwriter.Writer) {
+		w.Raw([]byte(`{"a":`))
+	}), sha256.New)
+	assert.Error(t, err)
+
+	_, err = HashWritable(writableFunc(func(w *jwriter.Writer) {
+		w.AddError(assert.AnError)
+	}), sha256.New)
+	assert.Equal(t, assert.AnError, err)
+}
+
+func TestHashWritableUsesNumberLiterals(t *testing.T) {
+	sum, err := HashWritable(writableFunc(func(w *jwriter.Writer) {
+		w.Number("18446744073709551617")
+	}), sha256.New)
+	require.NoError(t, err)
+	assert.Equal(t, hashWithReader(t, `18446744073709551616`), sum)
+}
+
+func TestHashWritableLargerThanBuffer(t *testing.T) {
+	input := "[" + strings.Repeat(`{"name":"abcdefghij","value":[1,2,3]},`, 100) + "null]"
+	require.Greater(t, len(input), writableBufferSize*2)
+	assert.Equal(t, hashWithReader(t, input), hashWithWritable(t, input))
+}
+
+func TestHasherErrors(t *testing.T) {
+	for _, p := range []struct {
+		input  string
+		offset int
+	}{
+		{``, 0},
+		{`   `, 3},
+		{`[1,2`, 4},
+		{`{"a":1`, 6},
+		{`"abc`, 4},
+		{`x`, 0},
+		{`[1,]`, 3},
+		{`[,1]`, 1},
+		{`[1 2]`, 3},
+		{`{,}`, 1},
+		{`{"a"}`, 4},
+		{`{"a":}`, 5},
+		{`{"a":1,}`, 7},
+		{`{1:2}`, 1},
+		{`{"a":1]`, 6},
+		{`[1}`, 2},
+		{`]`, 0},
+		{`{"a":]`, 5},
+		{`tru`, 3},
+		{`nulls`, 5},
+		{`01`, 2},
+		{`1.`, 2},
+		{`-`, 1},
+		{`1e`, 2},
+		{`1e400`, 5},
+		{`1 2`, 2},
+		{`{} x`, 3},
+		{`"a\x"`, 3},
+		{`"\u12g4"`, 5},
+		{"\"a\tb\"", 2},
+	} {
+		t.Run(p.input, func(t *testing.T) {
+			h := New(sha256.New)
+			_, err := h.Write([]byte(p.input))
+			if err == nil {
+				_, err = h.Sum()
+			}
+			require.Error(t, err)
+			require.IsType(t, jreader.SyntaxError{}, err)
+			assert.Equal(t, p.offset, err.(jreader.SyntaxError).Offset)
+
+			_, err = h.Write([]byte(`1`))
+			assert.Error(t, err, "Hasher should remain in a failed state")
+		})
+	}
+}
+
+func TestHasherLoneSurrogates(t *testing.T) {
+	expected := hashWithHasher(t, `"\ufffda\ufffd\ufffd\ufffd"`)
+	assert.Equal(t, expected, hashWithHasher(t, `"\ud83da\ude00\ud83d\ud83d"`))
+}
+
+func TestHasherReset(t *testing.T) {
+	h := New(sha256.New)
+	_, err := h.Write([]byte(`[1,`))
+	require.NoError(t, err)
+	_, err = h.Write([]byte(`]`))
+	require.Error(t, err)
+
+	h.Reset()
+	_, err = h.Write([]byte(`{"b":2,"a":1}`))
+	require.NoError(t, err)
+	sum, err := h.Sum()
+	require.NoError(t, err)
+	assert.Equal(t, hashWithReader(t, `{"a":1,"b":2}`), sum)
+
+	sum, err = h.Sum()
+	require.NoError(t, err)
+	assert.Equal(t, hashWithReader(t, `{"a":1,"b":2}`), sum, "Sum can be called more than once")
+}
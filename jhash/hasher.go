@@ -0,0 +1,390 @@
+package jhash
+
+import (
+	"hash"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+)
+
+const (
+	errMsgUnexpectedChar = "unexpected character"
+	errMsgUnexpectedEOF  = "unexpected end of input"
+	errMsgDataAfterEnd   = "unexpected data after end of JSON value"
+	errMsgInvalidString  = "invalid string"
+	errMsgInvalidNumber  = "invalid number"
+	errMsgInvalidLiteral = "invalid literal"
+)
+
+type parseMode int
+
+const (
+	modeValue parseMode = iota
+	modeAfterValue
+	modeName
+	modeColon
+	modeString
+	modeNumber
+	modeLiteral
+)
+
+// Hasher computes the structural hash of JSON text that is written to it in any number of pieces.
+// It implements io.Writer, so it can be the target of io.Copy or jwriter.NewStreamingWriter.
+//
+// The text must contain exactly one JSON value, optionally surrounded by whitespace. If it is
+// malformed, Write returns a jreader.SyntaxError whose Offset is the position of the error within
+// all of the text written so far, and the Hasher remains in a failed state until Reset is called.
+type Hasher struct {
+	state hashState
+	mode  parseMode
+	pos   int
+	err   error
+
+	// first is true if an array or object has just started, so it can be closed immediately.
+	first bool
+
+	// token holds the content of a string, number, or literal that is being parsed.
+	token  []byte
+	isName bool
+
+	// These are for parsing escape sequences within a string. If a \u escape is in progress, hexLeft
+	// is the number of digits remaining; highSurrogate is set if the last escape was the first half
+	// of a UTF-16 surrogate pair.
+	escaped       bool
+	hexLeft       int
+	hexValue      rune
+	highSurrogate rune
+}
+
+// New creates a Hasher that uses hash functions created by newHash.
+func New[H hash.Hash](newHash func() H) *Hasher {
+	return &Hasher{state: newHashState(func() hash.Hash { return newHash() })}
+}
+
+// Reset discards all data that has been written, and any error, so that the Hasher can be reused.
+func (h *Hasher) Reset() {
+	h.state.reset()
+	h.mode = modeValue
+	h.pos = 0
+	h.err = nil
+	h.first = false
+	h.token = h.token[:0]
+	h.escaped, h.hexLeft, h.highSurrogate = false, 0, 0
+}
+
+// Write parses more JSON text.
+func (h *Hasher) Write(data []byte) (int, error) {
+	if h.err != nil {
+		return 0, h.err
+	}
+	for i, ch := range data {
+		h.consume(ch)
+		if h.err != nil {
+			return i, h.err
+		}
+		h.pos++
+	}
+	return len(data), nil
+}
+
+// Sum returns the structural hash of the JSON value that was written. It returns an error if the
+// text so far was malformed or did not contain a complete value.
+func (h *Hasher) Sum() ([]byte, error) {
+	if h.err == nil {
+		switch h.mode { //nolint:exhaustive
+		case modeNumber:
+			h.endNumber()
+		case modeLiteral:
+			h.endLiteral()
+		}
+	}
+	if h.err == nil && !h.state.done {
+		h.fail(errMsgUnexpectedEOF, "")
+	}
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.state.sum(), nil
+}
+
+func (h *Hasher) fail(message, value string) {
+	h.err = jreader.SyntaxError{Message: message, Offset: h.pos, Value: value}
+}
+
+func (h *Hasher) failOnChar(message string, ch byte) {
+	h.fail(message, string(rune(ch)))
+}
+
+func (h *Hasher) consume(ch byte) {
+	switch h.mode { //nolint:exhaustive
+	case modeString:
+		h.consumeStringChar(ch)
+		return
+	case modeNumber:
+		if isNumberChar(ch) {
+			h.token = append(h.token, ch)
+			return
+		}
+		h.endNumber()
+		if h.err != nil {
+			return
+		}
+	case modeLiteral:
+		if ch >= 'a' && ch <= 'z' {
+			h.token = append(h.token, ch)
+			return
+		}
+		h.endLiteral()
+		if h.err != nil {
+			return
+		}
+	}
+	if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' {
+		return
+	}
+	switch h.mode { //nolint:exhaustive
+	case modeValue:
+		h.beginValue(ch)
+	case modeAfterValue:
+		h.afterValue(ch)
+	case modeName:
+		switch {
+		case ch == '"':
+			h.beginToken(modeString)
+			h.isName, h.first = true, false
+		case ch == '}' && h.first:
+			h.endContainer()
+		default:
+			h.failOnChar(errMsgUnexpectedChar, ch)
+		}
+	case modeColon:
+		if ch != ':' {
+			h.failOnChar(errMsgUnexpectedChar, ch)
+			return
+		}
+		h.mode = modeValue
+	}
+}
+
+func (h *Hasher) beginValue(ch byte) {
+	first := h.first
+	h.first = false
+	switch {
+	case ch == '{':
+		h.state.beginObject()
+		h.mode, h.first = modeName, true
+	case ch == '[':
+		h.state.beginArray()
+		h.mode, h.first = modeValue, true
+	case ch == ']' && first:
+		h.endContainer()
+	case ch == '"':
+		h.beginToken(modeString)
+		h.isName = false
+	case ch == '-' || (ch >= '0' && ch <= '9'):
+		h.beginToken(modeNumber)
+		h.token = append(h.token, ch)
+	case ch == 'n' || ch == 't' || ch == 'f':
+		h.beginToken(modeLiteral)
+		h.token = append(h.token, ch)
+	default:
+		h.failOnChar(errMsgUnexpectedChar, ch)
+	}
+}
+
+func (h *Hasher) afterValue(ch byte) {
+	if h.state.done {
+		h.failOnChar(errMsgDataAfterEnd, ch)
+		return
+	}
+	inObject := h.state.inObject()
+	switch {
+	case ch == ',' && inObject:
+		h.mode = modeName
+	case ch == ',':
+		h.mode = modeValue
+	case (ch == '}' && inObject) || (ch == ']' && !inObject):
+		h.endContainer()
+	default:
+		h.failOnChar(errMsgUnexpectedChar, ch)
+	}
+}
+
+func (h *Hasher) endContainer() {
+	if h.state.inObject() {
+		h.state.endObject()
+	} else {
+		h.state.endArray()
+	}
+	h.mode, h.first = modeAfterValue, false
+}
+
+func (h *Hasher) beginToken(mode parseMode) {
+	h.mode = mode
+	h.token = h.token[:0]
+}
+
+func (h *Hasher) consumeStringChar(ch byte) {
+	switch {
+	case h.hexLeft > 0:
+		digit, ok := hexDigitValue(ch)
+		if !ok {
+			h.failOnChar(errMsgInvalidString, ch)
+			return
+		}
+		h.hexValue = h.hexValue<<4 | digit
+		h.hexLeft--
+		if h.hexLeft == 0 {
+			h.addEscapedRune(h.hexValue)
+		}
+	case h.escaped:
+		h.escaped = false
+		if ch == 'u' {
+			h.hexLeft, h.hexValue = 4, 0
+			return
+		}
+		h.endSurrogate()
+		switch ch {
+		case '"', '\\', '/':
+			h.token = append(h.token, ch)
+		case 'b':
+			h.token = append(h.token, '\b')
+		case 'f':
+			h.token = append(h.token, '\f')
+		case 'n':
+			h.token = append(h.token, '\n')
+		case 'r':
+			h.token = append(h.token, '\r')
+		case 't':
+			h.token = append(h.token, '\t')
+		default:
+			h.failOnChar(errMsgInvalidString, ch)
+		}
+	case ch == '\\':
+		h.escaped = true
+	case ch == '"':
+		h.endSurrogate()
+		if h.isName {
+			h.state.name(h.token)
+			h.mode = modeColon
+		} else {
+			h.state.string(h.token)
+			h.mode = modeAfterValue
+		}
+	case ch < ' ':
+		h.failOnChar(errMsgInvalidString, ch)
+	default:
+		h.endSurrogate()
+		h.token = append(h.token, ch)
+	}
+}
+
+// addEscapedRune adds a character from a \u escape sequence, combining surrogate pairs. As in
+// encoding/json, a surrogate that is not part of a pair becomes U+FFFD.
+func (h *Hasher) addEscapedRune(r rune) {
+	if h.highSurrogate != 0 {
+		high := h.highSurrogate
+		h.highSurrogate = 0
+		if combined := utf16.DecodeRune(high, r); combined != utf8.RuneError {
+			h.token = utf8.AppendRune(h.token, combined)
+			return
+		}
+		h.token = utf8.AppendRune(h.token, utf8.RuneError)
+	}
+	if utf16.IsSurrogate(r) && r < 0xdc00 {
+		h.highSurrogate = r
+		return
+	}
+	if utf16.IsSurrogate(r) {
+		r = utf8.RuneError
+	}
+	h.token = utf8.AppendRune(h.token, r)
+}
+
+func (h *Hasher) endSurrogate() {
+	if h.highSurrogate != 0 {
+		h.token = utf8.AppendRune(h.token, utf8.RuneError)
+		h.highSurrogate = 0
+	}
+}
+
+func (h *Hasher) endNumber() {
+	h.mode = modeAfterValue
+	if !isValidNumber(h.token) {
+		h.fail(errMsgInvalidNumber, string(h.token))
+		return
+	}
+	value, err := strconv.ParseFloat(string(h.token), 64)
+	if err != nil {
+		h.fail(errMsgInvalidNumber, string(h.token))
+		return
+	}
+	h.state.number(value)
+}
+
+func (h *Hasher) endLiteral() {
+	h.mode = modeAfterValue
+	switch string(h.token) {
+	case "null":
+		h.state.null()
+	case "true":
+		h.state.bool(true)
+	case "false":
+		h.state.bool(false)
+	default:
+		h.fail(errMsgInvalidLiteral, string(h.token))
+	}
+}
+
+func isNumberChar(ch byte) bool {
+	return (ch >= '0' && ch <= '9') || ch == '-' || ch == '+' || ch == '.' || ch == 'e' || ch == 'E'
+}
+
+// isValidNumber checks the JSON number grammar, which is stricter than strconv.ParseFloat.
+func isValidNumber(s []byte) bool {
+	i := 0
+	digits := func() int {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i - start
+	}
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	if n := digits(); n == 0 || (n > 1 && s[i-n] == '0') {
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		if digits() == 0 {
+			return false
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		if digits() == 0 {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+func hexDigitValue(ch byte) (rune, bool) {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return rune(ch - '0'), true
+	case ch >= 'a' && ch <= 'f':
+		return rune(ch-'a') + 10, true
+	case ch >= 'A' && ch <= 'F':
+		return rune(ch-'A') + 10, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,29 @@
+// Package jhash computes structural hashes of JSON values.
+//
+// A structural hash depends only on the data that a JSON value represents, not on how it was
+// encoded: whitespace, the order of object members, the way strings are escaped, and the way
+// numbers are formatted do not affect it, but every type, value, and array element order does. For
+// instance, {"a": [1, 2], "b": "x"} and {"b":"x","a":[1.0,2e0]} have the same hash.
+//
+// The hash is computed in a single pass, without building an intermediate representation of the
+// value. The members of each object are hashed separately, and their digests are sorted when the
+// object ends; this sorts the member digests of one object at a time, not the whole document. The
+// memory used is one digest for each member of each object that is currently being read, and one
+// hash.Hash for each level of nesting. The hash algorithm is pluggable: any function that returns
+// a new hash.Hash, such as sha256.New or fnv.New64a, can be used.
+//
+//	r := jreader.NewReader(data)
+//	sum := jhash.HashReader(&r, sha256.New)
+//	if err := r.Error(); err != nil {
+//	    // the input was malformed
+//	}
+//
+// Values can also be hashed from a jwriter.Writable, or from JSON text that is written to a Hasher
+// in any number of pieces. Both of these parse the text as it arrives, without buffering the whole
+// document, and produce the same hash as HashReader does for the same data.
+//
+// Numbers are compared as 64-bit floating-point values, parsed from the original text of each
+// number, so numbers that differ only beyond the precision of a float64 have the same hash. A
+// structural hash is not the same as a hash of any serialized form of the value, so it can only be
+// compared with other hashes from this package that used the same hash function.
+package jhash
@@ -0,0 +1,185 @@
+package jhash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"math"
+	"sort"
+)
+
+// The data that is hashed for each value starts with one of these tags. Since every value is
+// either fixed-length or length-prefixed, and arrays are terminated by a byte that is not a tag,
+// the encoding of a sequence of values is unambiguous.
+const (
+	tagNull       byte = 'n'
+	tagFalse      byte = 'f'
+	tagTrue       byte = 't'
+	tagNumber     byte = 'd'
+	tagString     byte = 's'
+	tagArrayStart byte = '['
+	tagArrayEnd   byte = ']'
+	tagObject     byte = '{'
+)
+
+// hashState receives a sequence of JSON tokens and computes the structural hash.
+//
+// Scalar values and arrays are hashed in place. Each member of an object is hashed separately,
+// starting with its name, using a new hash.Hash for that level of nesting; when the object ends,
+// the member digests are sorted and hashed in place as the value of the object. This makes the
+// result independent of member order while still being sensitive to which value goes with which
+// name.
+type hashState struct {
+	newHash func() hash.Hash
+	root    hash.Hash
+	cur     hash.Hash
+	stack   []hashFrame
+	done    bool
+	scratch [binary.MaxVarintLen64 + 1]byte
+}
+
+type hashFrame struct {
+	isObject bool
+	member   hash.Hash
+	digests  [][]byte
+}
+
+func newHashState(newHash func() hash.Hash) hashState {
+	root := newHash()
+	return hashState{newHash: newHash, root: root, cur: root}
+}
+
+func (s *hashState) reset() {
+	s.root.Reset()
+	s.cur = s.root
+	s.stack = s.stack[:0]
+	s.done = false
+}
+
+func (s *hashState) sum() []byte {
+	return s.root.Sum(nil)
+}
+
+func (s *hashState) null() {
+	s.writeTag(tagNull)
+	s.endValue()
+}
+
+func (s *hashState) bool(value bool) {
+	if value {
+		s.writeTag(tagTrue)
+	} else {
+		s.writeTag(tagFalse)
+	}
+	s.endValue()
+}
+
+func (s *hashState) number(value float64) {
+	if value == 0 {
+		value = 0 // negative zero is the same number as zero
+	}
+	s.scratch[0] = tagNumber
+	binary.BigEndian.PutUint64(s.scratch[1:], math.Float64bits(value))
+	_, _ = s.cur.Write(s.scratch[:9])
+	s.endValue()
+}
+
+func (s *hashState) string(value []byte) {
+	s.writeTag(tagString)
+	s.writeBytes(value)
+	s.endValue()
+}
+
+func (s *hashState) beginArray() {
+	s.writeTag(tagArrayStart)
+	s.push(false)
+}
+
+func (s *hashState) endArray() {
+	s.writeTag(tagArrayEnd)
+	s.pop()
+	s.endValue()
+}
+
+func (s *hashState) beginObject() {
+	frame := s.push(true)
+	if frame.member == nil {
+		frame.member = s.newHash()
+	}
+	frame.member.Reset()
+	frame.digests = frame.digests[:0]
+	s.cur = frame.member
+}
+
+func (s *hashState) name(name []byte) {
+	s.writeBytes(name)
+}
+
+func (s *hashState) endObject() {
+	frame := s.pop()
+	digests := frame.digests
+	sort.Slice(digests, func(i, j int) bool { return bytes.Compare(digests[i], digests[j]) < 0 })
+	s.writeTag(tagObject)
+	s.writeLength(len(digests))
+	for _, d := range digests {
+		_, _ = s.cur.Write(d)
+	}
+	s.endValue()
+}
+
+// inObject returns true if the innermost array or object that is being hashed is an object.
+func (s *hashState) inObject() bool {
+	return len(s.stack) > 0 && s.stack[len(s.stack)-1].isObject
+}
+
+func (s *hashState) push(isObject bool) *hashFrame {
+	if len(s.stack) < cap(s.stack) {
+		s.stack = s.stack[:len(s.stack)+1]
+	} else {
+		s.stack = append(s.stack, hashFrame{})
+	}
+	frame := &s.stack[len(s.stack)-1]
+	frame.isObject = isObject
+	return frame
+}
+
+func (s *hashState) pop() *hashFrame {
+	frame := &s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	s.cur = s.root
+	for i := len(s.stack) - 1; i >= 0; i-- {
+		if s.stack[i].isObject {
+			s.cur = s.stack[i].member
+			break
+		}
+	}
+	return frame
+}
+
+// endValue is called after each complete value. If the value was an object member, it finishes
+// the digest for that member.
+func (s *hashState) endValue() {
+	if len(s.stack) == 0 {
+		s.done = true
+		return
+	}
+	frame := &s.stack[len(s.stack)-1]
+	if frame.isObject {
+		frame.digests = append(frame.digests, frame.member.Sum(nil))
+		frame.member.Reset()
+	}
+}
+
+func (s *hashState) writeTag(tag byte) {
+	s.scratch[0] = tag
+	_, _ = s.cur.Write(s.scratch[:1])
+}
+
+func (s *hashState) writeBytes(data []byte) {
+	s.writeLength(len(data))
+	_, _ = s.cur.Write(data)
+}
+
+func (s *hashState) writeLength(n int) {
+	_, _ = s.cur.Write(s.scratch[:binary.PutUvarint(s.scratch[:], uint64(n))])
+}
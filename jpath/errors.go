@@ -0,0 +1,20 @@
+package jpath
+
+import "fmt"
+
+// SyntaxError is returned by Compile if a string is not a valid JSONPath query.
+type SyntaxError struct {
+	// Query is the string that could not be parsed.
+	Query string
+
+	// Offset is the character index within the string where the error was found.
+	Offset int
+
+	// Message is a descriptive message.
+	Message string
+}
+
+// Error returns a description of the error.
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("invalid JSONPath query %q: %s at position %d", e.Query, e.Message, e.Offset)
+}
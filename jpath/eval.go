@@ -0,0 +1,251 @@
+package jpath
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+)
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+type selectorKind int
+
+const (
+	nameSelector selectorKind = iota
+	wildcardSelector
+	indexSelector
+	sliceSelector
+	filterSelector
+)
+
+type selector struct {
+	kind     selectorKind
+	name     string
+	index    int
+	start    int
+	end      int
+	step     int
+	hasStart bool
+	hasEnd   bool
+	filter   logicalExpr
+}
+
+// location is the position of a node within the document. It is a linked list from the node to
+// the root, so that many nodes can share the locations of their ancestors.
+type location struct {
+	parent  *location
+	name    string
+	index   int
+	isIndex bool
+}
+
+type node struct {
+	value jvalue.Value
+	loc   *location
+}
+
+// evalContext holds the state that filter expressions may need.
+type evalContext struct {
+	root jvalue.Value
+}
+
+func (l *location) child(name string) *location {
+	return &location{parent: l, name: name}
+}
+
+func (l *location) element(index int) *location {
+	return &location{parent: l, index: index, isIndex: true}
+}
+
+// normalizedPath returns the location as a normalized path (RFC 9535 section 2.7), such as
+// "$['items'][0]".
+func (l *location) normalizedPath() string {
+	var elements []*location
+	for e := l; e != nil; e = e.parent {
+		elements = append(elements, e)
+	}
+	var buf strings.Builder
+	buf.WriteByte('$')
+	for i := len(elements) - 1; i >= 0; i-- {
+		e := elements[i]
+		buf.WriteByte('[')
+		if e.isIndex {
+			buf.WriteString(strconv.Itoa(e.index))
+		} else {
+			writeNormalizedName(&buf, e.name)
+		}
+		buf.WriteByte(']')
+	}
+	return buf.String()
+}
+
+func writeNormalizedName(buf *strings.Builder, name string) {
+	const hexDigits = "0123456789abcdef"
+	buf.WriteByte('\'')
+	for _, ch := range name {
+		switch ch {
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\'':
+			buf.WriteString(`\'`)
+		case '\\':
+			buf.WriteString(`\\`)
+		default:
+			if ch < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[ch>>4])
+				buf.WriteByte(hexDigits[ch&0xf])
+			} else {
+				buf.WriteRune(ch)
+			}
+		}
+	}
+	buf.WriteByte('\'')
+}
+
+// evalSegments applies a sequence of segments to a list of nodes. The result of each segment is
+// the concatenation of its results for each input node, in order.
+func evalSegments(ctx *evalContext, nodes []node, segments []segment) []node {
+	for _, seg := range segments {
+		var results []node
+		for _, n := range nodes {
+			if seg.descendant {
+				results = seg.appendDescendantResults(ctx, n, results)
+			} else {
+				results = seg.appendResults(ctx, n, results)
+			}
+		}
+		nodes = results
+	}
+	return nodes
+}
+
+func (seg segment) appendResults(ctx *evalContext, n node, results []node) []node {
+	for _, sel := range seg.selectors {
+		results = sel.appendResults(ctx, n, results)
+	}
+	return results
+}
+
+// appendDescendantResults applies the segment's selectors to a node and all of its descendants,
+// visiting each node before its children.
+func (seg segment) appendDescendantResults(ctx *evalContext, n node, results []node) []node {
+	results = seg.appendResults(ctx, n, results)
+	switch n.value.Kind() {
+	case jreader.ArrayValue:
+		for i := 0; i < n.value.Len(); i++ {
+			results = seg.appendDescendantResults(ctx, node{n.value.Index(i), n.loc.element(i)}, results)
+		}
+	case jreader.ObjectValue:
+		for i := 0; i < n.value.Len(); i++ {
+			name, value := n.value.Member(i)
+			results = seg.appendDescendantResults(ctx, node{value, n.loc.child(name)}, results)
+		}
+	}
+	return results
+}
+
+func (sel selector) appendResults(ctx *evalContext, n node, results []node) []node {
+	v := n.value
+	switch v.Kind() {
+	case jreader.ArrayValue:
+		switch sel.kind {
+		case indexSelector:
+			if i, ok := sel.normalizedIndex(v.Len()); ok {
+				results = append(results, node{v.Index(i), n.loc.element(i)})
+			}
+		case sliceSelector:
+			sel.forEachSliceIndex(v.Len(), func(i int) {
+				results = append(results, node{v.Index(i), n.loc.element(i)})
+			})
+		case wildcardSelector, filterSelector:
+			for i := 0; i < v.Len(); i++ {
+				if sel.kind == wildcardSelector || sel.filter.test(ctx, v.Index(i)) {
+					results = append(results, node{v.Index(i), n.loc.element(i)})
+				}
+			}
+		}
+	case jreader.ObjectValue:
+		switch sel.kind {
+		case nameSelector:
+			if member, ok := v.Get(sel.name); ok {
+				results = append(results, node{member, n.loc.child(sel.name)})
+			}
+		case wildcardSelector, filterSelector:
+			for i := 0; i < v.Len(); i++ {
+				name, member := v.Member(i)
+				if sel.kind == wildcardSelector || sel.filter.test(ctx, member) {
+					results = append(results, node{member, n.loc.child(name)})
+				}
+			}
+		}
+	}
+	return results
+}
+
+// normalizedIndex converts an index selector, which may count back from the end of the array,
+// to an array index, and returns false if it is out of range.
+func (sel selector) normalizedIndex(length int) (int, bool) {
+	i := sel.index
+	if i < 0 {
+		i += length
+	}
+	return i, i >= 0 && i < length
+}
+
+// forEachSliceIndex calls fn for each array index selected by a slice selector, in the order
+// described by RFC 9535 section 2.3.4.2.
+func (sel selector) forEachSliceIndex(length int, fn func(int)) {
+	normalize := func(i int) int {
+		if i < 0 {
+			return length + i
+		}
+		return i
+	}
+	clamp := func(i, lower, upper int) int {
+		if i < lower {
+			return lower
+		}
+		if i > upper {
+			return upper
+		}
+		return i
+	}
+	switch {
+	case sel.step > 0:
+		start, end := 0, length
+		if sel.hasStart {
+			start = clamp(normalize(sel.start), 0, length)
+		}
+		if sel.hasEnd {
+			end = clamp(normalize(sel.end), 0, length)
+		}
+		for i := start; i < end; i += sel.step {
+			fn(i)
+		}
+	case sel.step < 0:
+		start, end := length-1, -1
+		if sel.hasStart {
+			start = clamp(normalize(sel.start), -1, length-1)
+		}
+		if sel.hasEnd {
+			end = clamp(normalize(sel.end), -1, length-1)
+		}
+		for i := start; i > end; i += sel.step {
+			fn(i)
+		}
+	}
+}
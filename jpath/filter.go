@@ -0,0 +1,347 @@
+package jpath
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+)
+
+// exprType is one of the types in the RFC 9535 function type system.
+type exprType int
+
+const (
+	valueType exprType = iota
+	logicalType
+	nodesType
+)
+
+// logicalExpr is a filter expression that produces true or false for the current node.
+type logicalExpr interface {
+	test(ctx *evalContext, current jvalue.Value) bool
+}
+
+// valueExpr is a filter expression that produces a single JSON value, or nothing.
+type valueExpr interface {
+	value(ctx *evalContext, current jvalue.Value) (jvalue.Value, bool)
+}
+
+type orExpr []logicalExpr
+
+type andExpr []logicalExpr
+
+type notExpr struct {
+	expr logicalExpr
+}
+
+type comparisonExpr struct {
+	op          string
+	left, right valueExpr
+}
+
+type existenceTest struct {
+	query *filterQuery
+}
+
+type functionTest struct {
+	call *functionCall
+}
+
+type literalValue struct {
+	v jvalue.Value
+}
+
+// filterQuery is a query within a filter expression, relative either to the current node ("@")
+// or to the root of the document ("$").
+type filterQuery struct {
+	relative bool
+	segments []segment
+}
+
+type singularQuery struct {
+	query *filterQuery
+}
+
+type function struct {
+	params []exprType
+	result exprType
+	eval   func(call *functionCall, args []interface{}) interface{}
+
+	// regexArg is true if the second argument is a regular expression, and regexFull is true if it
+	// must match the whole string rather than a substring.
+	regexArg, regexFull bool
+}
+
+type functionCall struct {
+	function *function
+	args     []interface{}
+
+	// regex is the compiled form of a constant pattern argument to match() or search().
+	regex *regexp.Regexp
+}
+
+//nolint:gochecknoglobals
+var functions = map[string]*function{
+	"length": {params: []exprType{valueType}, result: valueType, eval: evalLength},
+	"count":  {params: []exprType{nodesType}, result: valueType, eval: evalCount},
+	"match": {params: []exprType{valueType, valueType}, result: logicalType, eval: evalMatch,
+		regexArg: true, regexFull: true},
+	"search": {params: []exprType{valueType, valueType}, result: logicalType, eval: evalSearch, regexArg: true},
+	"value":  {params: []exprType{nodesType}, result: valueType, eval: evalValue},
+}
+
+func (e orExpr) test(ctx *evalContext, current jvalue.Value) bool {
+	for _, expr := range e {
+		if expr.test(ctx, current) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e andExpr) test(ctx *evalContext, current jvalue.Value) bool {
+	for _, expr := range e {
+		if !expr.test(ctx, current) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e notExpr) test(ctx *evalContext, current jvalue.Value) bool {
+	return !e.expr.test(ctx, current)
+}
+
+func (e comparisonExpr) test(ctx *evalContext, current jvalue.Value) bool {
+	left, leftExists := e.left.value(ctx, current)
+	right, rightExists := e.right.value(ctx, current)
+	switch e.op {
+	case "==":
+		return valuesEqual(left, leftExists, right, rightExists)
+	case "!=":
+		return !valuesEqual(left, leftExists, right, rightExists)
+	case "<":
+		return valueLess(left, leftExists, right, rightExists)
+	case "<=":
+		return valueLess(left, leftExists, right, rightExists) || valuesEqual(left, leftExists, right, rightExists)
+	case ">":
+		return valueLess(right, rightExists, left, leftExists)
+	default: // ">="
+		return valueLess(right, rightExists, left, leftExists) || valuesEqual(left, leftExists, right, rightExists)
+	}
+}
+
+func valuesEqual(a jvalue.Value, aExists bool, b jvalue.Value, bExists bool) bool {
+	if !aExists || !bExists {
+		return aExists == bExists
+	}
+	return a.Equal(b)
+}
+
+func valueLess(a jvalue.Value, aExists bool, b jvalue.Value, bExists bool) bool {
+	if !aExists || !bExists || a.Kind() != b.Kind() {
+		return false
+	}
+	switch a.Kind() { //nolint:exhaustive
+	case jreader.NumberValue:
+		return a.Float64Value() < b.Float64Value()
+	case jreader.StringValue:
+		// Comparing UTF-8 strings byte by byte gives the same result as comparing code points.
+		return a.StringValue() < b.StringValue()
+	default:
+		return false
+	}
+}
+
+func (e existenceTest) test(ctx *evalContext, current jvalue.Value) bool {
+	return len(e.query.nodes(ctx, current)) != 0
+}
+
+func (e functionTest) test(ctx *evalContext, current jvalue.Value) bool {
+	switch result := e.call.eval(ctx, current).(type) {
+	case bool:
+		return result
+	case []node:
+		return len(result) != 0
+	}
+	return false // COVERAGE: the parser only allows functions with a logical or node list result here
+}
+
+func (l literalValue) value(*evalContext, jvalue.Value) (jvalue.Value, bool) {
+	return l.v, true
+}
+
+func (q *filterQuery) nodes(ctx *evalContext, current jvalue.Value) []node {
+	start := current
+	if !q.relative {
+		start = ctx.root
+	}
+	return evalSegments(ctx, []node{{value: start}}, q.segments)
+}
+
+// isSingular returns true if the query can select at most one node.
+func (q *filterQuery) isSingular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 ||
+			(seg.selectors[0].kind != nameSelector && seg.selectors[0].kind != indexSelector) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q singularQuery) value(ctx *evalContext, current jvalue.Value) (jvalue.Value, bool) {
+	if nodes := q.query.nodes(ctx, current); len(nodes) == 1 {
+		return nodes[0].value, true
+	}
+	return jvalue.Value{}, false
+}
+
+// eval evaluates the arguments and calls the function. Arguments of value type are passed as a
+// *jvalue.Value that is nil if there is no value, and arguments of node list type are passed as
+// []node. The result is one of those types, or a bool.
+func (c *functionCall) eval(ctx *evalContext, current jvalue.Value) interface{} {
+	args := make([]interface{}, len(c.args))
+	for i, arg := range c.args {
+		switch a := arg.(type) {
+		case valueExpr:
+			if v, ok := a.value(ctx, current); ok {
+				args[i] = &v
+			} else {
+				args[i] = (*jvalue.Value)(nil)
+			}
+		case *filterQuery:
+			args[i] = a.nodes(ctx, current)
+		}
+	}
+	return c.function.eval(c, args)
+}
+
+func (c *functionCall) value(ctx *evalContext, current jvalue.Value) (jvalue.Value, bool) {
+	if v, _ := c.eval(ctx, current).(*jvalue.Value); v != nil {
+		return *v, true
+	}
+	return jvalue.Value{}, false
+}
+
+// compile does any preparation that can be done when the query is parsed.
+func (c *functionCall) compile() {
+	if !c.function.regexArg {
+		return
+	}
+	if pattern, ok := c.args[1].(literalValue); ok && pattern.v.Kind() == jreader.StringValue {
+		c.regex = compileIRegexp(pattern.v.StringValue(), c.function.regexFull)
+	}
+}
+
+func evalLength(_ *functionCall, args []interface{}) interface{} {
+	v := args[0].(*jvalue.Value)
+	if v == nil {
+		return v
+	}
+	var n int
+	switch v.Kind() { //nolint:exhaustive
+	case jreader.StringValue:
+		n = utf8.RuneCountInString(v.StringValue())
+	case jreader.ArrayValue, jreader.ObjectValue:
+		n = v.Len()
+	default:
+		return (*jvalue.Value)(nil)
+	}
+	result := jvalue.Int(n)
+	return &result
+}
+
+func evalCount(_ *functionCall, args []interface{}) interface{} {
+	result := jvalue.Int(len(args[0].([]node)))
+	return &result
+}
+
+func evalValue(_ *functionCall, args []interface{}) interface{} {
+	if nodes := args[0].([]node); len(nodes) == 1 {
+		return &nodes[0].value
+	}
+	return (*jvalue.Value)(nil)
+}
+
+func evalMatch(call *functionCall, args []interface{}) interface{} {
+	return evalRegexp(call, args, true)
+}
+
+func evalSearch(call *functionCall, args []interface{}) interface{} {
+	return evalRegexp(call, args, false)
+}
+
+func evalRegexp(call *functionCall, args []interface{}, full bool) bool {
+	s, pattern := args[0].(*jvalue.Value), args[1].(*jvalue.Value)
+	if s == nil || pattern == nil || s.Kind() != jreader.StringValue || pattern.Kind() != jreader.StringValue {
+		return false
+	}
+	re := call.regex
+	if re == nil {
+		if re = compileIRegexp(pattern.StringValue(), full); re == nil {
+			return false
+		}
+	}
+	return re.MatchString(s.StringValue())
+}
+
+// compileIRegexp translates an I-Regexp (RFC 9485) to Go's regular expression syntax, which is
+// nearly a superset of it, and compiles it. It returns nil if the pattern is not valid.
+//
+// The differences are that "." must not match a carriage return, "^" and "$" are ordinary
+// characters outside of a character class, and only a few backslash escapes are allowed.
+func compileIRegexp(pattern string, full bool) *regexp.Regexp {
+	var buf strings.Builder
+	if full {
+		buf.WriteString(`^(?:`)
+	}
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case ch == '\\':
+			if i+1 >= len(pattern) || !strings.ContainsRune(`\|.-^?*+{}()[]nrtpP`, rune(pattern[i+1])) {
+				return nil
+			}
+			buf.WriteString(pattern[i : i+2])
+			i++
+		case inClass:
+			if ch == ']' {
+				inClass = false
+			}
+			buf.WriteByte(ch)
+		case ch == '[':
+			inClass = true
+			buf.WriteByte(ch)
+			// A "]" at the start of a class, or after "^", is a literal rather than the end of the class.
+			if strings.HasPrefix(pattern[i+1:], "^") {
+				buf.WriteByte('^')
+				i++
+			}
+			if strings.HasPrefix(pattern[i+1:], "]") {
+				buf.WriteString(`\]`)
+				i++
+			}
+		case ch == '.':
+			buf.WriteString(`[^\n\r]`)
+		case ch == '^' || ch == '$':
+			buf.WriteByte('\\')
+			buf.WriteByte(ch)
+		case ch == '(' && strings.HasPrefix(pattern[i+1:], "?"):
+			return nil
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	if full {
+		buf.WriteString(`)$`)
+	}
+	re, err := regexp.Compile(buf.String())
+	if err != nil {
+		return nil
+	}
+	return re
+}
@@ -0,0 +1,26 @@
+// Package jpath evaluates JSONPath queries (RFC 9535) against JSON documents that are read with
+// jreader, and writes the results with jwriter.
+//
+// A query is compiled once with Compile, and can then be evaluated any number of times:
+//
+//	q, err := jpath.Compile(`$.store.book[?@.price < 10].title`)
+//	if err != nil {
+//	    return err
+//	}
+//	r := jreader.NewReader(data)
+//	w := jwriter.NewWriter()
+//	q.Evaluate(&r, &w) // writes a JSON array of the matching titles
+//
+// The full RFC 9535 syntax is supported, including name, index, wildcard, slice, and filter
+// selectors; descendant segments; and the standard function extensions length(), count(), match(),
+// search(), and value().
+//
+// Evaluation is streaming where the query allows it. As long as each segment of a query consists
+// of a single name, wildcard, non-negative index, or forward slice selector, the document is
+// traversed in one pass and values that are not selected are skipped without being parsed into Go
+// values. A filter selector buffers each array element or object member that it tests, one at a
+// time. Any other segment (a descendant segment, a segment with several selectors, or a negative
+// index or slice bound) needs to see the whole value that it is applied to, so it buffers that
+// value as a jvalue.Value, and the rest of the query is evaluated in memory. A query whose filters
+// refer to the root of the document with "$" buffers the whole document.
+package jpath
@@ -0,0 +1,544 @@
+package jpath
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+)
+
+const (
+	errMsgBadEscape        = "invalid escape sequence"
+	errMsgBadInteger       = "invalid integer"
+	errMsgBadNumber        = "invalid number"
+	errMsgBadSelector      = "expected a selector"
+	errMsgBadString        = "invalid character in string literal"
+	errMsgExpectedExpr     = "expected a query, function, literal, or '('"
+	errMsgExpectedRoot     = "query must start with '$'"
+	errMsgNonSingularQuery = "a query that is compared, or passed as a value, must be a singular query"
+	errMsgNotComparable    = "function result cannot be compared"
+	errMsgNotTestable      = "function result cannot be used as a test"
+	errMsgLiteralNotTest   = "a literal cannot be used as a test"
+	errMsgUnexpectedChar   = "unexpected character"
+	errMsgUnknownFunction  = "unknown function"
+	errMsgUnterminated     = "unterminated string literal"
+	errMsgWrongArgs        = "wrong number of function arguments"
+	errMsgWrongArgType     = "function argument has the wrong type"
+	maxExactInteger        = 1<<53 - 1
+	minExactInteger        = -maxExactInteger
+	blankChars             = " \t\n\r"
+)
+
+type parser struct {
+	query     string
+	pos       int
+	needsRoot bool
+}
+
+type parseError struct {
+	offset  int
+	message string
+}
+
+// parse parses a complete query. Errors are reported by panicking with a parseError, which
+// Compile recovers; this keeps the recursive descent code readable.
+func (p *parser) parse() []segment {
+	if !p.consume('$') {
+		p.fail(errMsgExpectedRoot)
+	}
+	segments := p.segments()
+	if p.pos < len(p.query) {
+		p.fail(errMsgUnexpectedChar)
+	}
+	return segments
+}
+
+func (p *parser) fail(message string) {
+	panic(parseError{offset: p.pos, message: message})
+}
+
+func (p *parser) failAt(offset int, message string) {
+	panic(parseError{offset: offset, message: message})
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.query) {
+		return p.query[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) consume(ch byte) bool {
+	if p.pos < len(p.query) && p.query[p.pos] == ch {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) consumeString(s string) bool {
+	if strings.HasPrefix(p.query[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(ch byte) {
+	if !p.consume(ch) {
+		p.fail("expected '" + string(ch) + "'")
+	}
+}
+
+func (p *parser) skipBlanks() {
+	for p.pos < len(p.query) && strings.IndexByte(blankChars, p.query[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// segments parses any number of segments, each of which may be preceded by whitespace. Whitespace
+// that is not followed by a segment is left unconsumed.
+func (p *parser) segments() []segment {
+	var segments []segment
+	for {
+		start := p.pos
+		p.skipBlanks()
+		switch {
+		case p.consumeString(".."):
+			if p.consume('[') {
+				segments = append(segments, segment{descendant: true, selectors: p.bracketedSelection()})
+			} else {
+				segments = append(segments, segment{descendant: true, selectors: p.shorthandSelector()})
+			}
+		case p.consume('.'):
+			segments = append(segments, segment{selectors: p.shorthandSelector()})
+		case p.consume('['):
+			segments = append(segments, segment{selectors: p.bracketedSelection()})
+		default:
+			p.pos = start
+			return segments
+		}
+	}
+}
+
+// shorthandSelector parses what follows "." or "..", if it is not a bracketed selection.
+func (p *parser) shorthandSelector() []selector {
+	if p.consume('*') {
+		return []selector{{kind: wildcardSelector}}
+	}
+	return []selector{{kind: nameSelector, name: p.memberNameShorthand()}}
+}
+
+func (p *parser) memberNameShorthand() string {
+	start := p.pos
+	for p.pos < len(p.query) {
+		ch, size := utf8.DecodeRuneInString(p.query[p.pos:])
+		isFirst := ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') ||
+			(ch >= 0x80 && ch != utf8.RuneError)
+		if !isFirst && (p.pos == start || ch < '0' || ch > '9') {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		p.fail("expected a property name or '*'")
+	}
+	return p.query[start:p.pos]
+}
+
+func (p *parser) bracketedSelection() []selector {
+	var selectors []selector
+	for {
+		p.skipBlanks()
+		selectors = append(selectors, p.selector())
+		p.skipBlanks()
+		if p.consume(']') {
+			return selectors
+		}
+		p.expect(',')
+	}
+}
+
+func (p *parser) selector() selector {
+	switch ch := p.peek(); {
+	case ch == '\'' || ch == '"':
+		return selector{kind: nameSelector, name: p.stringLiteral()}
+	case ch == '*':
+		p.pos++
+		return selector{kind: wildcardSelector}
+	case ch == '?':
+		p.pos++
+		p.skipBlanks()
+		return selector{kind: filterSelector, filter: p.logicalExpr()}
+	case ch == ':' || ch == '-' || (ch >= '0' && ch <= '9'):
+		return p.indexOrSlice()
+	default:
+		p.fail(errMsgBadSelector)
+		return selector{} // COVERAGE: fail never returns
+	}
+}
+
+func (p *parser) indexOrSlice() selector {
+	start, hasStart := p.optionalInteger()
+	p.skipBlanks()
+	if !p.consume(':') {
+		if !hasStart {
+			p.fail(errMsgBadSelector) // COVERAGE: selector only calls us for ':', '-', or a digit
+		}
+		return selector{kind: indexSelector, index: start}
+	}
+	sel := selector{kind: sliceSelector, start: start, hasStart: hasStart, step: 1}
+	p.skipBlanks()
+	sel.end, sel.hasEnd = p.optionalInteger()
+	p.skipBlanks()
+	if p.consume(':') {
+		p.skipBlanks()
+		if step, hasStep := p.optionalInteger(); hasStep {
+			sel.step = step
+		}
+	}
+	return sel
+}
+
+func (p *parser) optionalInteger() (int, bool) {
+	ch := p.peek()
+	if ch != '-' && (ch < '0' || ch > '9') {
+		return 0, false
+	}
+	start := p.pos
+	p.consume('-')
+	digitsStart := p.pos
+	for ch := p.peek(); ch >= '0' && ch <= '9'; ch = p.peek() {
+		p.pos++
+	}
+	digits := p.query[digitsStart:p.pos]
+	if digits == "" || (digits[0] == '0' && (len(digits) > 1 || digitsStart > start)) {
+		p.failAt(start, errMsgBadInteger)
+	}
+	n, err := strconv.ParseInt(p.query[start:p.pos], 10, 64)
+	if err != nil || n < minExactInteger || n > maxExactInteger {
+		p.failAt(start, errMsgBadInteger)
+	}
+	return int(n), true
+}
+
+// stringLiteral parses a single- or double-quoted string, which can contain the same escape
+// sequences as a JSON string, plus an escaped quote character of the same kind that delimits it.
+func (p *parser) stringLiteral() string {
+	start := p.pos
+	quote := p.query[p.pos]
+	p.pos++
+	var buf strings.Builder
+	for {
+		if p.pos >= len(p.query) {
+			p.failAt(start, errMsgUnterminated)
+		}
+		ch := p.query[p.pos]
+		switch {
+		case ch == quote:
+			p.pos++
+			return buf.String()
+		case ch < 0x20:
+			p.fail(errMsgBadString)
+		case ch == '\\':
+			p.escapeSequence(quote, &buf)
+		default:
+			r, size := utf8.DecodeRuneInString(p.query[p.pos:])
+			if r == utf8.RuneError && size == 1 {
+				p.fail(errMsgBadString)
+			}
+			buf.WriteString(p.query[p.pos : p.pos+size])
+			p.pos += size
+		}
+	}
+}
+
+func (p *parser) escapeSequence(quote byte, buf *strings.Builder) {
+	start := p.pos
+	p.pos++
+	ch := p.peek()
+	p.pos++
+	switch ch {
+	case 'b':
+		buf.WriteByte('\b')
+	case 'f':
+		buf.WriteByte('\f')
+	case 'n':
+		buf.WriteByte('\n')
+	case 'r':
+		buf.WriteByte('\r')
+	case 't':
+		buf.WriteByte('\t')
+	case '/', '\\':
+		buf.WriteByte(ch)
+	case 'u':
+		r := p.hexChar(start)
+		if utf16.IsSurrogate(r) {
+			if r >= 0xdc00 || !p.consumeString(`\u`) {
+				p.failAt(start, errMsgBadEscape)
+			}
+			r = utf16.DecodeRune(r, p.hexChar(start))
+			if r == utf8.RuneError {
+				p.failAt(start, errMsgBadEscape)
+			}
+		}
+		buf.WriteRune(r)
+	default:
+		if ch != quote {
+			p.failAt(start, errMsgBadEscape)
+		}
+		buf.WriteByte(ch)
+	}
+}
+
+func (p *parser) hexChar(escapeStart int) rune {
+	if p.pos+4 > len(p.query) {
+		p.failAt(escapeStart, errMsgBadEscape)
+	}
+	n, err := strconv.ParseUint(p.query[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		p.failAt(escapeStart, errMsgBadEscape)
+	}
+	p.pos += 4
+	return rune(n)
+}
+
+func (p *parser) logicalExpr() logicalExpr {
+	var or orExpr
+	for {
+		var and andExpr
+		for {
+			and = append(and, p.basicExpr())
+			p.skipBlanks()
+			if !p.consumeString("&&") {
+				break
+			}
+			p.skipBlanks()
+		}
+		if len(and) == 1 {
+			or = append(or, and[0])
+		} else {
+			or = append(or, and)
+		}
+		if !p.consumeString("||") {
+			break
+		}
+		p.skipBlanks()
+	}
+	if len(or) == 1 {
+		return or[0]
+	}
+	return or
+}
+
+func (p *parser) basicExpr() logicalExpr {
+	if p.consume('!') {
+		p.skipBlanks()
+		if p.peek() == '(' {
+			return notExpr{p.parenExpr()}
+		}
+		start := p.pos
+		operand := p.operand()
+		p.skipBlanks()
+		if p.comparisonOperator() != "" {
+			p.failAt(start, "'!' cannot be applied to a comparison without parentheses")
+		}
+		return notExpr{p.testExpr(start, operand)}
+	}
+	if p.peek() == '(' {
+		return p.parenExpr()
+	}
+	start := p.pos
+	left := p.operand()
+	p.skipBlanks()
+	op := p.comparisonOperator()
+	if op == "" {
+		return p.testExpr(start, left)
+	}
+	p.skipBlanks()
+	rightStart := p.pos
+	right := p.operand()
+	return comparisonExpr{
+		op:    op,
+		left:  p.valueOperand(start, left),
+		right: p.valueOperand(rightStart, right),
+	}
+}
+
+func (p *parser) parenExpr() logicalExpr {
+	p.expect('(')
+	p.skipBlanks()
+	expr := p.logicalExpr()
+	p.skipBlanks()
+	p.expect(')')
+	return expr
+}
+
+func (p *parser) comparisonOperator() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consumeString(op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// operand parses a literal, a filter query, or a function call. Its type is checked by the
+// caller, depending on where it is used.
+func (p *parser) operand() interface{} {
+	ch := p.peek()
+	switch {
+	case ch == '@' || ch == '$':
+		return p.filterQuery()
+	case ch == '\'' || ch == '"':
+		return literalValue{jvalue.String(p.stringLiteral())}
+	case ch == '-' || (ch >= '0' && ch <= '9'):
+		return p.numberLiteral()
+	case ch >= 'a' && ch <= 'z':
+		start := p.pos
+		for ch := p.peek(); (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '_'; ch = p.peek() {
+			p.pos++
+		}
+		name := p.query[start:p.pos]
+		if p.peek() == '(' {
+			return p.functionCall(start, name)
+		}
+		switch name {
+		case "true":
+			return literalValue{jvalue.Bool(true)}
+		case "false":
+			return literalValue{jvalue.Bool(false)}
+		case "null":
+			return literalValue{jvalue.Null()}
+		}
+		p.failAt(start, errMsgExpectedExpr)
+	}
+	p.fail(errMsgExpectedExpr)
+	return nil // COVERAGE: fail never returns
+}
+
+func (p *parser) filterQuery() *filterQuery {
+	relative := p.query[p.pos] == '@'
+	p.pos++
+	if !relative {
+		p.needsRoot = true
+	}
+	return &filterQuery{relative: relative, segments: p.segments()}
+}
+
+func (p *parser) numberLiteral() literalValue {
+	start := p.pos
+	p.consume('-')
+	digitsStart := p.pos
+	p.digits()
+	if p.pos == digitsStart || (p.query[digitsStart] == '0' && p.pos > digitsStart+1) {
+		p.failAt(start, errMsgBadNumber)
+	}
+	if p.consume('.') && !p.digits() {
+		p.failAt(start, errMsgBadNumber)
+	}
+	if p.consume('e') || p.consume('E') {
+		if !p.consume('-') {
+			p.consume('+')
+		}
+		if !p.digits() {
+			p.failAt(start, errMsgBadNumber)
+		}
+	}
+	n, err := strconv.ParseFloat(p.query[start:p.pos], 64)
+	if err != nil {
+		p.failAt(start, errMsgBadNumber)
+	}
+	return literalValue{jvalue.Number(n)}
+}
+
+func (p *parser) digits() bool {
+	start := p.pos
+	for ch := p.peek(); ch >= '0' && ch <= '9'; ch = p.peek() {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *parser) functionCall(start int, name string) *functionCall {
+	fn, ok := functions[name]
+	if !ok {
+		p.failAt(start, errMsgUnknownFunction)
+	}
+	p.expect('(')
+	p.skipBlanks()
+	call := &functionCall{function: fn}
+	if !p.consume(')') {
+		for {
+			argStart := p.pos
+			if len(call.args) == len(fn.params) {
+				p.fail(errMsgWrongArgs)
+			}
+			call.args = append(call.args, p.argument(argStart, fn.params[len(call.args)]))
+			p.skipBlanks()
+			if p.consume(')') {
+				break
+			}
+			p.expect(',')
+			p.skipBlanks()
+		}
+	}
+	if len(call.args) != len(fn.params) {
+		p.failAt(start, errMsgWrongArgs)
+	}
+	call.compile()
+	return call
+}
+
+func (p *parser) argument(start int, paramType exprType) interface{} {
+	operand := p.operand()
+	switch paramType {
+	case valueType:
+		return p.valueOperand(start, operand)
+	default: // nodesType
+		if q, ok := operand.(*filterQuery); ok {
+			return q
+		}
+		p.failAt(start, errMsgWrongArgType)
+		return nil // COVERAGE: fail never returns
+	}
+}
+
+// valueOperand checks that an operand produces a single value: a literal, a singular query, or a
+// function whose result type is a value.
+func (p *parser) valueOperand(start int, operand interface{}) valueExpr {
+	switch o := operand.(type) {
+	case literalValue:
+		return o
+	case *filterQuery:
+		if !o.isSingular() {
+			p.failAt(start, errMsgNonSingularQuery)
+		}
+		return singularQuery{o}
+	case *functionCall:
+		if o.function.result != valueType {
+			p.failAt(start, errMsgNotComparable)
+		}
+		return o
+	}
+	return nil // COVERAGE: operand only returns the types above
+}
+
+// testExpr checks that an operand can be used as a test: a query, which tests whether any nodes
+// exist, or a function whose result type is logical or a node list.
+func (p *parser) testExpr(start int, operand interface{}) logicalExpr {
+	switch o := operand.(type) {
+	case *filterQuery:
+		return existenceTest{o}
+	case *functionCall:
+		if o.function.result == valueType {
+			p.failAt(start, errMsgNotTestable)
+		}
+		return functionTest{o}
+	default:
+		p.failAt(start, errMsgLiteralNotTest)
+		return nil // COVERAGE: fail never returns
+	}
+}
@@ -0,0 +1,213 @@
+package jpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileValidQueries(t *testing.T) {
+	for _, query := range []string{
+		`$`,
+		`$.a`,
+		`$._a1`,
+		`$.été`,
+		`$.*`,
+		`$..a`,
+		`$..*`,
+		`$..['a']`,
+		`$[ 'a' , "b" ]`,
+		`$['\'']`,
+		`$["\""]`,
+		`$['"']`,
+		`$["'"]`,
+		`$['\b\f\n\r\t\/\\']`,
+		`$['é😀']`,
+		`$[0]`,
+		`$[-1]`,
+		`$[9007199254740991]`,
+		`$[-9007199254740991]`,
+		`$[:]`,
+		`$[::]`,
+		`$[1:]`,
+		`$[:2]`,
+		`$[1:2:3]`,
+		`$[ 1 : 2 : 3 ]`,
+		`$[::-1]`,
+		`$[*, 0, 'a', 1:2, ?@]`,
+		"$\t.a\n[0]\r ['b']",
+		`$[?@]`,
+		`$[?$]`,
+		`$[?@.a]`,
+		`$[?!@.a]`,
+		`$[? @.a == 1 ]`,
+		`$[?@.a==-0]`,
+		`$[?@.a==1.5e-3]`,
+		`$[?@.a==1E+3]`,
+		`$[?@.a==true]`,
+		`$[?@.a==false]`,
+		`$[?@.a==null]`,
+		`$[?@.a=="x"]`,
+		`$[?@.a=='x']`,
+		`$[?@.a==@.b]`,
+		`$[?@.a==$.b[0]]`,
+		`$[?@.a==@['b'][-1]]`,
+		`$[?(@.a)]`,
+		`$[?!(@.a)]`,
+		`$[?(@.a == 1 || @.b) && !(@.c)]`,
+		`$[?length(@) == 1]`,
+		`$[?length(@.a) == 1]`,
+		`$[?length('abc') == 3]`,
+		`$[?length(value(@..a)) == 3]`,
+		`$[?count(@.*) == 1]`,
+		`$[?count(@..a) == 1]`,
+		`$[?match(@.a, 'x')]`,
+		`$[?search(@.a, @.b)]`,
+		`$[?!match(@.a, 'x')]`,
+		`$[?value(@.a) == 1]`,
+		`$[?@[?@.b]]`,
+		`$[?@.a[?@ > 1]]`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			q, err := Compile(query)
+			require.NoError(t, err)
+			assert.Equal(t, query, q.String())
+		})
+	}
+}
+
+func TestCompileInvalidQueries(t *testing.T) {
+	for _, p := range []struct {
+		query  string
+		offset int
+	}{
+		{``, 0},
+		{`a`, 0},
+		{` $`, 0},
+		{`$ `, 1},
+		{`$a`, 1},
+		{`$.`, 2},
+		{`$.1`, 2},
+		{`$.-`, 2},
+		{`$..`, 3},
+		{`$...a`, 3},
+		{`$.['a']`, 2},
+		{`$[`, 2},
+		{`$[]`, 2},
+		{`$[0`, 3},
+		{`$[0 1]`, 4},
+		{`$[0,]`, 4},
+		{`$[a]`, 2},
+		{`$['a`, 2},
+		{`$['a'`, 5},
+		{`$["a']`, 2},
+		{"$['\x01']", 3},
+		{`$['\a']`, 3},
+		{`$['\"']`, 3},
+		{`$["\'"]`, 3},
+		{`$['\u00']`, 3},
+		{`$['\uXYZW']`, 3},
+		{`$['\uDE00']`, 3},
+		{`$['\uD83D']`, 3},
+		{`$['\uD83DA']`, 3},
+		{`$[01]`, 2},
+		{`$[-0]`, 2},
+		{`$[-]`, 2},
+		{`$[9007199254740992]`, 2},
+		{`$[-9007199254740992]`, 2},
+		{`$[0:1:2:3]`, 7},
+		{`$[1:a]`, 4},
+		{`$[?]`, 3},
+		{`$[?1]`, 3},
+		{`$[?'a']`, 3},
+		{`$[?true]`, 3},
+		{`$[?@.a == ]`, 10},
+		{`$[?@.a === 1]`, 9},
+		{`$[?@.a = 1]`, 7},
+		{`$[?@.a == 01]`, 10},
+		{`$[?@.a == 1.]`, 10},
+		{`$[?@.a == 1e]`, 10},
+		{`$[?@.a == -]`, 10},
+		{`$[?@.a == tru]`, 10},
+		{`$[?@.a == ['x']]`, 10},
+		{`$[?@.* == 1]`, 3},
+		{`$[?@..a == 1]`, 3},
+		{`$[?@[0, 1] == 1]`, 3},
+		{`$[?@[0:1] == 1]`, 3},
+		{`1 == @.a`, 0},
+		{`$[?1 == @.*]`, 8},
+		{`$[?!@.a == 1]`, 4},
+		{`$[?(@.a]`, 7},
+		{`$[?@.a &&]`, 9},
+		{`$[?@.a || ]`, 10},
+		{`$[?foo(@.a)]`, 3},
+		{`$[?Length(@.a) == 1]`, 3},
+		{`$[?length (@.a) == 1]`, 3},
+		{`$[?length(@.a)]`, 3},
+		{`$[?length(@.*) == 1]`, 10},
+		{`$[?length(@.a, 1) == 1]`, 15},
+		{`$[?length() == 1]`, 3},
+		{`$[?count(1) == 1]`, 9},
+		{`$[?count(@.a)]`, 3},
+		{`$[?match(@.a) == 1]`, 3},
+		{`$[?match(@.a, 'x') == true]`, 3},
+		{`$[?value(@.a)]`, 3},
+		{`$[?length(match(@.a, 'x')) == 1]`, 10},
+	} {
+		t.Run(p.query, func(t *testing.T) {
+			q, err := Compile(p.query)
+			assert.Nil(t, q)
+			require.Error(t, err)
+			require.IsType(t, SyntaxError{}, err)
+			se := err.(SyntaxError)
+			assert.Equal(t, p.query, se.Query)
+			assert.Equal(t, p.offset, se.Offset, se.Error())
+		})
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	assert.Equal(t, `$.a`, MustCompile(`$.a`).String())
+	assert.Panics(t, func() { MustCompile(`a`) })
+}
+
+func TestCompileIRegexp(t *testing.T) {
+	for _, p := range []struct {
+		pattern string
+		s       string
+		full    bool
+		search  bool
+	}{
+		{`a.c`, "abc", true, true},
+		{`a.c`, "a\nc", false, false},
+		{`a.c`, "a\rc", false, false},
+		{`a^b`, "a^b", true, true},
+		{`a$`, "a$", true, true},
+		{`a$`, "a", false, false},
+		{`[^.]`, "x", true, true},
+		{`[.]`, "x", false, false},
+		{`[]a]+`, "]a", true, true},
+		{`[^]a]`, "b", true, true},
+		{`\p{Lu}+`, "ABC", true, true},
+		{`\.`, ".", true, true},
+		{`b`, "abc", false, true},
+		{`x|y`, "y", true, true},
+		{`(ab){2}`, "abab", true, true},
+	} {
+		t.Run(p.pattern+" "+p.s, func(t *testing.T) {
+			full := compileIRegexp(p.pattern, true)
+			require.NotNil(t, full)
+			assert.Equal(t, p.full, full.MatchString(p.s))
+			search := compileIRegexp(p.pattern, false)
+			require.NotNil(t, search)
+			assert.Equal(t, p.search, search.MatchString(p.s))
+		})
+	}
+
+	for _, pattern := range []string{`(`, `\d`, `\`, `(?i)a`, `a{2,1}`} {
+		t.Run(pattern, func(t *testing.T) {
+			assert.Nil(t, compileIRegexp(pattern, true))
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package jpath
+
+import (
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+// Query is a compiled JSONPath query. It is immutable, so it can be reused, and used from
+// multiple goroutines at once.
+type Query struct {
+	expression string
+	segments   []segment
+
+	// needsRoot is true if a filter refers to the root of the document, in which case we must
+	// buffer the whole document.
+	needsRoot bool
+}
+
+// Compile parses a JSONPath query. If the string is not a valid query, or it is not well-typed
+// according to RFC 9535 (for instance, it compares the result of a query that can select more
+// than one node), it returns nil and a SyntaxError.
+func Compile(expression string) (*Query, error) {
+	p := parser{query: expression}
+	segments, err := p.parseAll()
+	if err != nil {
+		return nil, err
+	}
+	return &Query{expression: expression, segments: segments, needsRoot: p.needsRoot}, nil
+}
+
+// MustCompile is the same as Compile, but panics if the query is not valid. It is meant for
+// initializing queries from constant strings.
+func MustCompile(expression string) *Query {
+	q, err := Compile(expression)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+func (p *parser) parseAll() (segments []segment, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pe, ok := r.(parseError)
+			if !ok {
+				panic(r) // COVERAGE: the parser only panics with parseError
+			}
+			err = SyntaxError{Query: p.query, Offset: pe.offset, Message: pe.message}
+		}
+	}()
+	return p.parse(), nil
+}
+
+// String returns the query in the form that it was given to Compile.
+func (q *Query) String() string {
+	return q.expression
+}
+
+// Evaluate reads the next JSON value from the Reader, and writes a JSON array of the values that
+// the query selects from it to the Writer.
+//
+// Values that were traversed in streaming mode (see package documentation) are copied to the
+// output exactly as they appeared in the input, including any whitespace within them; values that
+// were buffered are written in compact form.
+//
+// If there is a parsing error, the Reader enters a failed state, which you can detect with Error(),
+// and the same error is added to the Writer.
+func (q *Query) Evaluate(r *jreader.Reader, w *jwriter.Writer) {
+	arr := w.Array()
+	q.run(r, func(loc *location, buffered *jvalue.Value) {
+		if buffered != nil {
+			buffered.WriteToJSONWriter(w)
+		} else {
+			arr.Raw(r.RawValue())
+		}
+	})
+	if err := r.Error(); err != nil {
+		w.AddError(err)
+		return
+	}
+	arr.End()
+}
+
+// EvaluatePaths is the same as Evaluate, except that instead of the selected values, it writes
+// their locations, as normalized paths (RFC 9535 section 2.7) such as "$['items'][0]".
+func (q *Query) EvaluatePaths(r *jreader.Reader, w *jwriter.Writer) {
+	arr := w.Array()
+	q.run(r, func(loc *location, buffered *jvalue.Value) {
+		if buffered == nil {
+			_ = r.SkipValue()
+		}
+		arr.String(loc.normalizedPath())
+	})
+	if err := r.Error(); err != nil {
+		w.AddError(err)
+		return
+	}
+	arr.End()
+}
+
+// Select returns the values that the query selects from a value that has already been parsed.
+func (q *Query) Select(v jvalue.Value) []jvalue.Value {
+	nodes := evalSegments(&evalContext{root: v}, []node{{value: v}}, q.segments)
+	if len(nodes) == 0 {
+		return nil
+	}
+	ret := make([]jvalue.Value, len(nodes))
+	for i, n := range nodes {
+		ret[i] = n.value
+	}
+	return ret
+}
+
+// EvaluateJSON is a shortcut for compiling a query, evaluating it against a complete JSON
+// document, and returning the JSON array of results.
+func EvaluateJSON(data []byte, expression string) ([]byte, error) {
+	q, err := Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	r := jreader.NewReader(data)
+	w := jwriter.NewWriter()
+	q.Evaluate(&r, &w)
+	if err := r.RequireEOF(); err != nil {
+		return nil, err
+	}
+	if err := w.Error(); err != nil {
+		return nil, err // COVERAGE: the only possible writer error would have come from the reader
+	}
+	return w.Bytes(), nil
+}
@@ -0,0 +1,34 @@
+package jpath
+
+import (
+	"fmt"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+func ExampleQuery_Evaluate() {
+	q := MustCompile(`$.items[?@.price < 10].name`)
+	data := []byte(`{"items": [{"name": "a", "price": 5}, {"name": "b", "price": 15}, {"name": "c", "price": 1}]}`)
+	r := jreader.NewReader(data)
+	w := jwriter.NewWriter()
+	q.Evaluate(&r, &w)
+	fmt.Println(string(w.Bytes()))
+	// Output: ["a","c"]
+}
+
+func ExampleQuery_EvaluatePaths() {
+	q := MustCompile(`$..name`)
+	data := []byte(`{"items": [{"name": "a"}, {"name": "b"}], "name": "c"}`)
+	r := jreader.NewReader(data)
+	w := jwriter.NewWriter()
+	q.EvaluatePaths(&r, &w)
+	fmt.Println(string(w.Bytes()))
+	// Output: ["$['name']","$['items'][0]['name']","$['items'][1]['name']"]
+}
+
+func ExampleEvaluateJSON() {
+	result, err := EvaluateJSON([]byte(`[1, 2, 3, 4, 5]`), `$[-2:]`)
+	fmt.Println(string(result), err)
+	// Output: [4,5] <nil>
+}
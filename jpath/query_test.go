@@ -0,0 +1,383 @@
+package jpath
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// This is the example document from RFC 9535 section 1.5.
+const storeDocument = `{ "store": {
+    "book": [
+      { "category": "reference",
+        "author": "Nigel Rees",
+        "title": "Sayings of the Century",
+        "price": 8.95
+      },
+      { "category": "fiction",
+        "author": "Evelyn Waugh",
+        "title": "Sword of Honour",
+        "price": 12.99
+      },
+      { "category": "fiction",
+        "author": "Herman Melville",
+        "title": "Moby Dick",
+        "isbn": "0-553-21311-3",
+        "price": 8.99
+      },
+      { "category": "fiction",
+        "author": "J. R. R. Tolkien",
+        "title": "The Lord of the Rings",
+        "isbn": "0-395-19395-8",
+        "price": 22.99
+      }
+    ],
+    "bicycle": {
+      "color": "red",
+      "price": 399
+    }
+  }
+}`
+
+type queryTestCase struct {
+	query    string
+	document string
+	values   string
+	paths    string
+}
+
+func (tc queryTestCase) run(t *testing.T) {
+	q, err := Compile(tc.query)
+	require.NoError(t, err)
+
+	expectedValues, err := jvalue.Parse([]byte(tc.values))
+	require.NoError(t, err)
+
+	t.Run("Evaluate", func(t *testing.T) {
+		r := jreader.NewReader([]byte(tc.document))
+		w := jwriter.NewWriter()
+		q.Evaluate(&r, &w)
+		require.NoError(t, r.Error())
+		require.NoError(t, w.Error())
+		actual, err := jvalue.Parse(w.Bytes())
+		require.NoError(t, err)
+		assert.True(t, expectedValues.Equal(actual), "expected %s, got %s", expectedValues, actual)
+	})
+
+	t.Run("EvaluatePaths", func(t *testing.T) {
+		r := jreader.NewReader([]byte(tc.document))
+		w := jwriter.NewWriter()
+		q.EvaluatePaths(&r, &w)
+		require.NoError(t, r.Error())
+		require.NoError(t, w.Error())
+		assert.JSONEq(t, tc.paths, string(w.Bytes()))
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		doc, err := jvalue.Parse([]byte(tc.document))
+		require.NoError(t, err)
+		actual := jvalue.ArrayOf(q.Select(doc)...)
+		assert.True(t, expectedValues.Equal(actual), "expected %s, got %s", expectedValues, actual)
+	})
+}
+
+func TestStoreExamples(t *testing.T) {
+	// These are the examples from RFC 9535 section 1.5, with results in the order that the RFC
+	// requires (where it allows more than one order, in document order).
+	for _, tc := range []queryTestCase{
+		{
+			query:  `$.store.book[*].author`,
+			values: `["Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"]`,
+			paths: `["$['store']['book'][0]['author']", "$['store']['book'][1]['author']",
+				"$['store']['book'][2]['author']", "$['store']['book'][3]['author']"]`,
+		},
+		{
+			query:  `$..author`,
+			values: `["Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"]`,
+			paths: `["$['store']['book'][0]['author']", "$['store']['book'][1]['author']",
+				"$['store']['book'][2]['author']", "$['store']['book'][3]['author']"]`,
+		},
+		{
+			query:  `$.store..price`,
+			values: `[8.95, 12.99, 8.99, 22.99, 399]`,
+			paths: `["$['store']['book'][0]['price']", "$['store']['book'][1]['price']",
+				"$['store']['book'][2]['price']", "$['store']['book'][3]['price']",
+				"$['store']['bicycle']['price']"]`,
+		},
+		{
+			query: `$..book[2]`,
+			values: `[{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick",
+				"isbn": "0-553-21311-3", "price": 8.99}]`,
+			paths: `["$['store']['book'][2]"]`,
+		},
+		{
+			query:  `$..book[2].author`,
+			values: `["Herman Melville"]`,
+			paths:  `["$['store']['book'][2]['author']"]`,
+		},
+		{
+			query:  `$..book[2].publisher`,
+			values: `[]`,
+			paths:  `[]`,
+		},
+		{
+			query:  `$..book[-1].title`,
+			values: `["The Lord of the Rings"]`,
+			paths:  `["$['store']['book'][3]['title']"]`,
+		},
+		{
+			query:  `$..book[0,1].title`,
+			values: `["Sayings of the Century", "Sword of Honour"]`,
+			paths:  `["$['store']['book'][0]['title']", "$['store']['book'][1]['title']"]`,
+		},
+		{
+			query:  `$..book[:2].title`,
+			values: `["Sayings of the Century", "Sword of Honour"]`,
+			paths:  `["$['store']['book'][0]['title']", "$['store']['book'][1]['title']"]`,
+		},
+		{
+			query:  `$..book[?@.isbn].title`,
+			values: `["Moby Dick", "The Lord of the Rings"]`,
+			paths:  `["$['store']['book'][2]['title']", "$['store']['book'][3]['title']"]`,
+		},
+		{
+			query:  `$..book[?@.price<10].title`,
+			values: `["Sayings of the Century", "Moby Dick"]`,
+			paths:  `["$['store']['book'][0]['title']", "$['store']['book'][2]['title']"]`,
+		},
+		{
+			query:  `$..*[?@ == $.store.bicycle.color]`,
+			values: `["red"]`,
+			paths:  `["$['store']['bicycle']['color']"]`,
+		},
+	} {
+		tc.document = storeDocument
+		t.Run(tc.query, tc.run)
+	}
+}
+
+func TestSelectors(t *testing.T) {
+	const array = `["a", "b", "c", "d", "e", "f", "g"]`
+	const object = `{"a": 1, "b": [2, 3], "c": {"d": 4}, "e'f": 5, "": 6}`
+	for _, tc := range []queryTestCase{
+		{query: `$`, document: array, values: `[` + array + `]`, paths: `["$"]`},
+		{query: `$.a`, document: object, values: `[1]`, paths: `["$['a']"]`},
+		{query: `$['a']`, document: object, values: `[1]`, paths: `["$['a']"]`},
+		{query: `$["e'f"]`, document: object, values: `[5]`, paths: `["$['e\\'f']"]`},
+		{query: `$['']`, document: object, values: `[6]`, paths: `["$['']"]`},
+		{query: `$.x`, document: object, values: `[]`, paths: `[]`},
+		{query: `$.a`, document: array, values: `[]`, paths: `[]`},
+		{query: `$.b[1]`, document: object, values: `[3]`, paths: `["$['b'][1]"]`},
+		{query: `$.c.d`, document: object, values: `[4]`, paths: `["$['c']['d']"]`},
+		{query: `$.*`, document: object, values: `[1, [2, 3], {"d": 4}, 5, 6]`,
+			paths: `["$['a']", "$['b']", "$['c']", "$['e\\'f']", "$['']"]`},
+		{query: `$[*]`, document: `[1, [2]]`, values: `[1, [2]]`, paths: `["$[0]", "$[1]"]`},
+		{query: `$[1]`, document: array, values: `["b"]`, paths: `["$[1]"]`},
+		{query: `$[-2]`, document: array, values: `["f"]`, paths: `["$[5]"]`},
+		{query: `$[7]`, document: array, values: `[]`, paths: `[]`},
+		{query: `$[-8]`, document: array, values: `[]`, paths: `[]`},
+		{query: `$[0]`, document: object, values: `[]`, paths: `[]`},
+		{query: `$[1:3]`, document: array, values: `["b", "c"]`, paths: `["$[1]", "$[2]"]`},
+		{query: `$[5:]`, document: array, values: `["f", "g"]`, paths: `["$[5]", "$[6]"]`},
+		{query: `$[1:5:2]`, document: array, values: `["b", "d"]`, paths: `["$[1]", "$[3]"]`},
+		{query: `$[::3]`, document: array, values: `["a", "d", "g"]`, paths: `["$[0]", "$[3]", "$[6]"]`},
+		{query: `$[5:1:-2]`, document: array, values: `["f", "d"]`, paths: `["$[5]", "$[3]"]`},
+		{query: `$[::-1]`, document: `[1, 2, 3]`, values: `[3, 2, 1]`, paths: `["$[2]", "$[1]", "$[0]"]`},
+		{query: `$[-2:]`, document: array, values: `["f", "g"]`, paths: `["$[5]", "$[6]"]`},
+		{query: `$[:-5]`, document: array, values: `["a", "b"]`, paths: `["$[0]", "$[1]"]`},
+		{query: `$[-100:100]`, document: `[1, 2]`, values: `[1, 2]`, paths: `["$[0]", "$[1]"]`},
+		{query: `$[::0]`, document: array, values: `[]`, paths: `[]`},
+		{query: `$[1:1]`, document: array, values: `[]`, paths: `[]`},
+		{query: `$[0:2]`, document: object, values: `[]`, paths: `[]`},
+		{query: `$[1, 0, 1]`, document: array, values: `["b", "a", "b"]`, paths: `["$[1]", "$[0]", "$[1]"]`},
+		{query: `$['b', 'a']`, document: object, values: `[[2, 3], 1]`, paths: `["$['b']", "$['a']"]`},
+		{query: `$[0, 0:2]`, document: array, values: `["a", "a", "b"]`, paths: `["$[0]", "$[0]", "$[1]"]`},
+		{query: `$..[0]`, document: `[[1, [2]], [3]]`, values: `[[1, [2]], 1, 2, 3]`,
+			paths: `["$[0]", "$[0][0]", "$[0][1][0]", "$[1][0]"]`},
+		{query: `$..*`, document: `{"a": [1, {"b": 2}]}`, values: `[[1, {"b": 2}], 1, {"b": 2}, 2]`,
+			paths: `["$['a']", "$['a'][0]", "$['a'][1]", "$['a'][1]['b']"]`},
+		{query: `$..a..b`, document: `{"a": {"b": 1, "a": {"b": 2}}}`, values: `[1, 2, 2]`,
+			paths: `["$['a']['b']", "$['a']['a']['b']", "$['a']['a']['b']"]`},
+		{query: `$.a[1]..b`, document: `{"a": [0, {"x": {"b": 1}, "b": 2}]}`, values: `[2, 1]`,
+			paths: `["$['a'][1]['b']", "$['a'][1]['x']['b']"]`},
+		{query: "$ .a [0]", document: `{"a": [1]}`, values: `[1]`, paths: `["$['a'][0]"]`},
+		{query: `$['\u00e9\n']`, document: "{\"\u00e9\\n\": 1}", values: `[1]`, paths: `["$['\u00e9\\n']"]`},
+		{query: `$['\u0001']`, document: `{"\u0001": 1}`, values: `[1]`, paths: `["$['\\u0001']"]`},
+	} {
+		t.Run(tc.query+" "+tc.document, tc.run)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	const document = `[
+		{"a": 1, "b": "x"},
+		{"a": 2, "b": "yy", "c": [1, 2, 3]},
+		{"a": 3.0, "b": "zzz", "c": {"d": true}},
+		{"a": null, "b": null},
+		{"b": ["x"]},
+		5,
+		"str"
+	]`
+	for _, p := range []struct {
+		filter   string
+		expected string
+	}{
+		{`@.a`, `[0, 1, 2, 3]`},
+		{`!@.a`, `[4, 5, 6]`},
+		{`@.a == 1`, `[0]`},
+		{`@.a == 3`, `[2]`},
+		{`1 == @.a`, `[0]`},
+		{`@.a != 1`, `[1, 2, 3, 4, 5, 6]`},
+		{`@.a == null`, `[3]`},
+		{`@.a < 2`, `[0]`},
+		{`@.a <= 2`, `[0, 1]`},
+		{`@.a > 1`, `[1, 2]`},
+		{`@.a >= 1.5e0`, `[1, 2]`},
+		{`@.a > -1`, `[0, 1, 2]`},
+		{`@.b < 'y'`, `[0]`},
+		{`@.b >= "yy"`, `[1, 2]`},
+		{`@.x == @.y`, `[0, 1, 2, 3, 4, 5, 6]`},
+		{`@.x < @.y`, `[]`},
+		{`@.x <= @.y`, `[0, 1, 2, 3, 4, 5, 6]`},
+		{`@.c == @.c`, `[0, 1, 2, 3, 4, 5, 6]`},
+		{`@.c[0] == 1`, `[1]`},
+		{`@.c.d == true`, `[2]`},
+		{`@.c.d`, `[2]`},
+		{`@ == 5`, `[5]`},
+		{`@ > 4`, `[5]`},
+		{`@ == 'str'`, `[6]`},
+		{`@.a == 1 || @.a == 2`, `[0, 1]`},
+		{`@.a && @.c`, `[1, 2]`},
+		{`@.a && (@.b == 'x' || @.c)`, `[0, 1, 2]`},
+		{`!(@.a == 1) && @.a`, `[1, 2, 3]`},
+		{`@.a || @.b && @.c`, `[0, 1, 2, 3]`},
+		{`@.c[*]`, `[1, 2]`},
+		{`@..d`, `[2]`},
+		{`$[5] == 5`, `[0, 1, 2, 3, 4, 5, 6]`},
+		{`$[0].a == @.a`, `[0]`},
+		{`length(@.b) == 2`, `[1]`},
+		{`length(@.b) == 1`, `[0, 4]`},
+		{`length(@) == 3`, `[1, 2, 6]`},
+		{`length(@.c) >= 1`, `[1, 2]`},
+		{`length(@.a) == 1`, `[]`},
+		{`count(@.*) == 2`, `[0, 3]`},
+		{`count(@..*) > 3`, `[1, 2]`},
+		{`match(@.b, 'y+')`, `[1]`},
+		{`match(@.b, 'y')`, `[]`},
+		{`search(@.b, 'y')`, `[1]`},
+		{`search(@.b, '^y')`, `[]`},
+		{`match(@.b, '[x-y]+')`, `[0, 1]`},
+		{`match(@, 's.r')`, `[6]`},
+		{`!match(@.b, 'x')`, `[1, 2, 3, 4, 5, 6]`},
+		{`match(@.b, $[6])`, `[]`},
+		{`search(@.b, @.b)`, `[0, 1, 2]`},
+		{`match(@.b, '(')`, `[]`},
+		{`value(@.c[*]) == 1`, `[]`},
+		{`value(@.c.*) == true`, `[2]`},
+		{`value(@..d) == true`, `[2]`},
+	} {
+		t.Run(p.filter, func(t *testing.T) {
+			q, err := Compile("$[?" + p.filter + "]")
+			require.NoError(t, err)
+			doc, err := jvalue.Parse([]byte(document))
+			require.NoError(t, err)
+			var expectedIndexes []int
+			require.NoError(t, jreader.UnmarshalJSONWithReader([]byte(p.expected), readIntSlice(&expectedIndexes)))
+			var expectedValues []jvalue.Value
+			for _, i := range expectedIndexes {
+				expectedValues = append(expectedValues, doc.Index(i))
+			}
+			assert.Equal(t, jvalue.ArrayOf(expectedValues...).String(), jvalue.ArrayOf(q.Select(doc)...).String())
+
+			result, err := EvaluateJSON([]byte(document), q.String())
+			require.NoError(t, err)
+			actual, err := jvalue.Parse(result)
+			require.NoError(t, err)
+			assert.True(t, jvalue.ArrayOf(expectedValues...).Equal(actual))
+		})
+	}
+}
+
+type intSliceReader struct {
+	target *[]int
+}
+
+func readIntSlice(target *[]int) intSliceReader {
+	return intSliceReader{target}
+}
+
+func (s intSliceReader) ReadFromJSONReader(r *jreader.Reader) {
+	*s.target = jreader.ReadSlice(r, (*jreader.Reader).Int)
+}
+
+func TestNumberLiteralsArePreserved(t *testing.T) {
+	for _, query := range []string{`$.a[*]`, `$.a[0, 1]`, `$..a[*]`, `$.a[?@ > 0]`} {
+		t.Run(query, func(t *testing.T) {
+			result, err := EvaluateJSON([]byte(`{"a": [1.0, 12345678901234567890123]}`), query)
+			require.NoError(t, err)
+			assert.Equal(t, `[1.0,12345678901234567890123]`, string(result))
+		})
+	}
+}
+
+func TestStreamingSkipsUnselectedValues(t *testing.T) {
+	// Values that are not selected are skipped without being parsed into Go values, but are still
+	// checked for syntax errors.
+	result, err := EvaluateJSON([]byte(`{"a": [{"x": 1}, {"x": 2}], "b": {"c": [true, false]}}`), `$.a[1].x`)
+	require.NoError(t, err)
+	assert.Equal(t, `[2]`, string(result))
+
+	_, err = EvaluateJSON([]byte(`{"a": [{"x": 1}, {"x": 2}], "b": {"c": [true, x]}}`), `$.a[1].x`)
+	assert.IsType(t, jreader.SyntaxError{}, err)
+}
+
+func TestStreamingSelectorsThatDoNotMatchContainer(t *testing.T) {
+	for _, tc := range []queryTestCase{
+		{`$.*.k`, `{"a": [1, {"k": 2}], "b": {"k": 3}, "c": "x"}`, `[3]`, `["$['b']['k']"]`},
+		{`$.*[0]`, `{"a": {"x": 1}, "b": [2, 3], "c": [[4]]}`, `[2, [4]]`, `["$['b'][0]", "$['c'][0]"]`},
+		{`$.a.x`, `{"a": [{"x": 1}], "b": 2}`, `[]`, `[]`},
+		{`$[0]`, `{"a": [1]}`, `[]`, `[]`},
+		{`$[1:]`, `{"a": 1, "b": 2}`, `[]`, `[]`},
+		{`$[0].k`, `[{"k": [1, 2]}, {"k": 3}]`, `[[1, 2]]`, `["$[0]['k']"]`},
+	} {
+		t.Run(tc.query, tc.run)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	for _, query := range []string{`$.a`, `$.a[*]`, `$.a[?@.b]`, `$..a`, `$[?$.a]`} {
+		t.Run(query, func(t *testing.T) {
+			q := MustCompile(query)
+
+			r := jreader.NewReader([]byte(`{"a": [{"b": x}]}`))
+			w := jwriter.NewWriter()
+			q.Evaluate(&r, &w)
+			assert.IsType(t, jreader.SyntaxError{}, r.Error())
+			assert.Equal(t, r.Error(), w.Error())
+
+			r = jreader.NewReader([]byte(`{"a": [{"b": x}]}`))
+			w = jwriter.NewWriter()
+			q.EvaluatePaths(&r, &w)
+			assert.IsType(t, jreader.SyntaxError{}, r.Error())
+			assert.Equal(t, r.Error(), w.Error())
+
+			_, err := EvaluateJSON([]byte(`{"a": [{"b": x}]}`), query)
+			assert.IsType(t, jreader.SyntaxError{}, err)
+		})
+	}
+
+	t.Run("extra data", func(t *testing.T) {
+		_, err := EvaluateJSON([]byte(`{"a": 1} 2`), `$.a`)
+		assert.IsType(t, jreader.SyntaxError{}, err)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := EvaluateJSON([]byte(`{"a": 1}`), `a`)
+		assert.IsType(t, SyntaxError{}, err)
+	})
+}
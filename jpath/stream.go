@@ -0,0 +1,106 @@
+package jpath
+
+import (
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jvalue"
+)
+
+// emitFunc is called for each selected node. If the node was buffered, its value is passed;
+// otherwise, the value is nil and emitFunc must consume the next value from the Reader.
+type emitFunc func(loc *location, buffered *jvalue.Value)
+
+// run evaluates the query against the next value from the Reader, streaming as much of it as
+// possible.
+func (q *Query) run(r *jreader.Reader, emit emitFunc) {
+	if q.needsRoot {
+		root := jvalue.Read(r)
+		if r.Error() == nil {
+			emitNodes(evalSegments(&evalContext{root: root}, []node{{value: root}}, q.segments), emit)
+		}
+		return
+	}
+	streamSegments(r, q.segments, nil, emit)
+}
+
+func emitNodes(nodes []node, emit emitFunc) {
+	for i := range nodes {
+		emit(nodes[i].loc, &nodes[i].value)
+	}
+}
+
+// streamSegments applies a sequence of segments to the next value from the Reader.
+func streamSegments(r *jreader.Reader, segments []segment, loc *location, emit emitFunc) {
+	if len(segments) == 0 {
+		emit(loc, nil)
+		return
+	}
+	seg := segments[0]
+	if !seg.isStreamable() {
+		v := jvalue.Read(r)
+		if r.Error() == nil {
+			emitNodes(evalSegments(&evalContext{}, []node{{v, loc}}, segments), emit)
+		}
+		return
+	}
+	sel := seg.selectors[0]
+	value := r.Any()
+	switch value.Kind { //nolint:exhaustive
+	case jreader.ArrayValue:
+		// Unselected elements, including all elements when the selector is a name, are skipped by
+		// Next; the array must still be consumed even if nothing in it can match.
+		nextSlice := sel.start
+		for i := 0; value.Array.Next(); i++ {
+			switch sel.kind {
+			case nameSelector:
+				continue
+			case indexSelector:
+				if i != sel.index {
+					continue
+				}
+			case sliceSelector:
+				if i != nextSlice || (sel.hasEnd && i >= sel.end) {
+					continue
+				}
+				nextSlice += sel.step
+			}
+			streamChild(r, sel, segments[1:], loc.element(i), emit)
+		}
+	case jreader.ObjectValue:
+		for value.Object.Next() {
+			name := value.Object.Name()
+			if sel.kind == indexSelector || sel.kind == sliceSelector ||
+				(sel.kind == nameSelector && string(name) != sel.name) {
+				continue
+			}
+			streamChild(r, sel, segments[1:], loc.child(string(name)), emit)
+		}
+	}
+}
+
+func streamChild(r *jreader.Reader, sel selector, segments []segment, loc *location, emit emitFunc) {
+	if sel.kind != filterSelector {
+		streamSegments(r, segments, loc, emit)
+		return
+	}
+	v := jvalue.Read(r)
+	if r.Error() == nil && sel.filter.test(&evalContext{}, v) {
+		emitNodes(evalSegments(&evalContext{}, []node{{v, loc}}, segments), emit)
+	}
+}
+
+// isStreamable returns true if the segment selects children of a value in the order that they
+// appear in the document, each at most once, so that it can be evaluated in a single pass.
+func (seg segment) isStreamable() bool {
+	if seg.descendant || len(seg.selectors) != 1 {
+		return false
+	}
+	sel := seg.selectors[0]
+	switch sel.kind {
+	case indexSelector:
+		return sel.index >= 0
+	case sliceSelector:
+		return sel.step > 0 && (!sel.hasStart || sel.start >= 0) && (!sel.hasEnd || sel.end >= 0)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,13 @@
+// Package jpointer implements JSON Pointer (RFC 6901) expressions, which identify a specific value
+// within a JSON document.
+//
+// A pointer is a sequence of reference tokens, each of which is either an object property name or
+// an array index. In string form, each token is preceded by a slash, and any "~" or "/" characters
+// within a token are escaped as "~0" and "~1". The empty string refers to the whole document.
+//
+//	p, err := jpointer.Parse("/items/0/key")
+//	// p.Tokens() is []string{"items", "0", "key"}
+//
+// This package has no dependencies on the rest of go-jsonstream. See jreader.Reader.Seek and
+// jvalue.Value.Find for ways to use a Pointer.
+package jpointer
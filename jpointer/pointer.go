@@ -0,0 +1,163 @@
+package jpointer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const errMsgBadEscape = "'~' must be followed by '0' or '1'"
+
+// Pointer is a parsed JSON Pointer. The zero value is an empty pointer, which refers to the whole
+// document. A Pointer is immutable.
+type Pointer struct {
+	tokens []string
+}
+
+// SyntaxError is returned by Parse if a string is not a valid JSON Pointer.
+type SyntaxError struct {
+	// Pointer is the string that could not be parsed.
+	Pointer string
+
+	// Offset is the character index within the string where the error was found.
+	Offset int
+
+	// Message is a descriptive message.
+	Message string
+}
+
+// Error returns a description of the error.
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("invalid JSON Pointer %q: %s at position %d", e.Pointer, e.Message, e.Offset)
+}
+
+// Parse parses a JSON Pointer from its string form. The string must be either empty, or a sequence
+// of reference tokens that each begin with "/"; within a token, "~" may only appear as part of the
+// escape sequences "~0" and "~1".
+func Parse(s string) (Pointer, error) {
+	if s == "" {
+		return Pointer{}, nil
+	}
+	if s[0] != '/' {
+		return Pointer{}, SyntaxError{Pointer: s, Offset: 0, Message: "must be empty or start with '/'"}
+	}
+	tokens := strings.Split(s[1:], "/")
+	offset := 1
+	for i, token := range tokens {
+		unescaped, badOffset := unescape(token)
+		if badOffset >= 0 {
+			return Pointer{}, SyntaxError{Pointer: s, Offset: offset + badOffset, Message: errMsgBadEscape}
+		}
+		tokens[i] = unescaped
+		offset += len(token) + 1
+	}
+	return Pointer{tokens: tokens}, nil
+}
+
+// MustParse is the same as Parse, but panics if the string is not a valid JSON Pointer. It is
+// meant for initializing pointers from constant strings.
+func MustParse(s string) Pointer {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// New creates a Pointer from a sequence of unescaped reference tokens. Tokens that are array
+// indexes are given as decimal strings.
+func New(tokens ...string) Pointer {
+	if len(tokens) == 0 {
+		return Pointer{}
+	}
+	return Pointer{tokens: append([]string(nil), tokens...)}
+}
+
+// Len returns the number of reference tokens.
+func (p Pointer) Len() int {
+	return len(p.tokens)
+}
+
+// Token returns the unescaped reference token at the specified position.
+func (p Pointer) Token(index int) string {
+	return p.tokens[index]
+}
+
+// Tokens returns a copy of the unescaped reference tokens.
+func (p Pointer) Tokens() []string {
+	return append([]string(nil), p.tokens...)
+}
+
+// Append returns a new Pointer with additional unescaped reference tokens added to the end.
+func (p Pointer) Append(tokens ...string) Pointer {
+	ret := make([]string, 0, len(p.tokens)+len(tokens))
+	return Pointer{tokens: append(append(ret, p.tokens...), tokens...)}
+}
+
+// String returns the string form of the pointer, with each token escaped.
+func (p Pointer) String() string {
+	var sb strings.Builder
+	for _, token := range p.tokens {
+		sb.WriteByte('/')
+		sb.WriteString(Escape(token))
+	}
+	return sb.String()
+}
+
+// Escape escapes a reference token for use in the string form of a pointer, replacing "~" with
+// "~0" and "/" with "~1".
+func Escape(token string) string {
+	if !strings.ContainsAny(token, "~/") {
+		return token
+	}
+	return tokenEscaper.Replace(token)
+}
+
+// Unescape reverses the escaping of a reference token. It returns a SyntaxError if the token
+// contains a "~" that is not part of a valid escape sequence.
+func Unescape(token string) (string, error) {
+	unescaped, badOffset := unescape(token)
+	if badOffset >= 0 {
+		return "", SyntaxError{Pointer: token, Offset: badOffset, Message: errMsgBadEscape}
+	}
+	return unescaped, nil
+}
+
+// unescape returns the unescaped token and -1, or the offset of an invalid escape sequence.
+func unescape(token string) (string, int) {
+	if !strings.Contains(token, "~") {
+		return token, -1
+	}
+	var sb strings.Builder
+	for i := 0; i < len(token); i++ {
+		ch := token[i]
+		if ch != '~' {
+			sb.WriteByte(ch)
+			continue
+		}
+		if i+1 >= len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+			return "", i
+		}
+		if token[i+1] == '0' {
+			sb.WriteByte('~')
+		} else {
+			sb.WriteByte('/')
+		}
+		i++
+	}
+	return sb.String(), -1
+}
+
+// ArrayIndex interprets a reference token as an array index. It returns false if the token is
+// not a non-negative decimal integer without leading zeroes, as RFC 6901 requires. In particular,
+// the token "-", which RFC 6901 uses to refer to the nonexistent element after the end of an array,
+// is not an index.
+func ArrayIndex(token string) (int, bool) {
+	if token == "" || token[0] < '0' || token[0] > '9' || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	n, err := strconv.Atoi(token)
+	return n, err == nil
+}
+
+var tokenEscaper = strings.NewReplacer("~", "~0", "/", "~1") //nolint:gochecknoglobals
@@ -0,0 +1,15 @@
+package jpointer
+
+import "fmt"
+
+func ExampleParse() {
+	p, err := Parse("/items/0/a~1b")
+	fmt.Printf("%q %v\n", p.Tokens(), err)
+	// Output: ["items" "0" "a/b"] <nil>
+}
+
+func ExampleNew() {
+	p := New("items", "0", "a/b")
+	fmt.Println(p)
+	// Output: /items/0/a~1b
+}
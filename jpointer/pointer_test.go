@@ -0,0 +1,110 @@
+package jpointer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, p := range []struct {
+		s      string
+		tokens []string
+	}{
+		{``, nil},
+		{`/`, []string{""}},
+		{`/a`, []string{"a"}},
+		{`/a/0/b`, []string{"a", "0", "b"}},
+		{`//`, []string{"", ""}},
+		{`/a~1b`, []string{"a/b"}},
+		{`/m~0n`, []string{"m~n"}},
+		{`/~01`, []string{"~1"}},
+		{`/~10`, []string{"/0"}},
+		{`/ %"\`, []string{` %"\`}},
+	} {
+		t.Run(p.s, func(t *testing.T) {
+			pointer, err := Parse(p.s)
+			require.NoError(t, err)
+			assert.Equal(t, p.tokens, pointer.Tokens())
+			assert.Equal(t, len(p.tokens), pointer.Len())
+			assert.Equal(t, p.s, pointer.String())
+			assert.Equal(t, pointer, New(p.tokens...))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, p := range []struct {
+		s      string
+		offset int
+	}{
+		{`a`, 0},
+		{`a/b`, 0},
+		{`/~`, 1},
+		{`/a/b~2`, 4},
+		{`/a~/`, 2},
+	} {
+		t.Run(p.s, func(t *testing.T) {
+			_, err := Parse(p.s)
+			require.Error(t, err)
+			require.IsType(t, SyntaxError{}, err)
+			assert.Equal(t, p.s, err.(SyntaxError).Pointer)
+			assert.Equal(t, p.offset, err.(SyntaxError).Offset)
+			assert.Contains(t, err.Error(), p.s)
+		})
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	assert.Equal(t, New("a", "b"), MustParse("/a/b"))
+	assert.Panics(t, func() { MustParse("a") })
+}
+
+func TestAccessors(t *testing.T) {
+	p := New("a", "b/c")
+	assert.Equal(t, "a", p.Token(0))
+	assert.Equal(t, "b/c", p.Token(1))
+
+	tokens := p.Tokens()
+	tokens[0] = "x"
+	assert.Equal(t, "a", p.Token(0), "Tokens returns a copy")
+
+	assert.Equal(t, 0, Pointer{}.Len())
+	assert.Equal(t, "", Pointer{}.String())
+}
+
+func TestAppend(t *testing.T) {
+	p := MustParse("/a")
+	p1 := p.Append("b")
+	p2 := p.Append("c", "d")
+	assert.Equal(t, "/a", p.String())
+	assert.Equal(t, "/a/b", p1.String())
+	assert.Equal(t, "/a/c/d", p2.String())
+	assert.Equal(t, "/x", Pointer{}.Append("x").String())
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, "abc", Escape("abc"))
+	assert.Equal(t, "a~1b~0c", Escape("a/b~c"))
+	assert.Equal(t, "~01", Escape("~1"))
+
+	s, err := Unescape("a~1b~0c")
+	assert.NoError(t, err)
+	assert.Equal(t, "a/b~c", s)
+
+	_, err = Unescape("a~b")
+	assert.Error(t, err)
+}
+
+func TestArrayIndex(t *testing.T) {
+	for token, expected := range map[string]int{"0": 0, "1": 1, "10": 10, "123": 123} {
+		n, ok := ArrayIndex(token)
+		assert.True(t, ok, token)
+		assert.Equal(t, expected, n, token)
+	}
+	for _, token := range []string{"", "-", "-1", "01", "00", "1a", "a", " 1", "+1", "1e2", "99999999999999999999"} {
+		_, ok := ArrayIndex(token)
+		assert.False(t, ok, token)
+	}
+}
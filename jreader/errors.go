@@ -56,6 +56,29 @@ type RequiredPropertyError struct {
 	Offset int
 }
 
+// RangeError is returned by Reader if a number was read into a Go numeric type that cannot
+// represent it, because it was out of range or was not an integer.
+type RangeError struct {
+	// Value is the number as it appeared in the input.
+	Value string
+
+	// Type is the Go type that the number was to be read into.
+	Type reflect.Type
+
+	// Offset is the approximate character index within the input where the error occurred.
+	Offset int
+}
+
+// UnsupportedTypeError is returned by ReadInto if the target value's type, or the type of some
+// value within it, cannot be read from JSON.
+type UnsupportedTypeError struct {
+	// Type is the Go type that could not be read.
+	Type reflect.Type
+
+	// Offset is the approximate character index within the input where the error occurred.
+	Offset int
+}
+
 // Error returns a description of the error.
 func (e SyntaxError) Error() string {
 	if e.Value != "" {
@@ -77,6 +100,16 @@ func (e RequiredPropertyError) Error() string {
 	return fmt.Sprintf("a required property %q was missing from a JSON object at position %d", e.Name, e.Offset)
 }
 
+// Error returns a description of the error.
+func (e RangeError) Error() string {
+	return fmt.Sprintf("number %s cannot be represented as Go type %s at position %d", e.Value, e.Type, e.Offset)
+}
+
+// Error returns a description of the error.
+func (e UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("cannot read a JSON value into Go type %s at position %d", e.Type, e.Offset)
+}
+
 // ToJSONError converts errors defined by the jreader package into the corresponding error types defined
 // by the encoding/json package, if any. The target parameter, if not nil, is used to determine the
 // target value type for json.UnmarshalTypeError.
@@ -92,6 +125,12 @@ func ToJSONError(err error, target interface{}) error {
 			Type:   reflect.TypeOf(target),
 			Offset: int64(e.Offset),
 		}
+	case RangeError:
+		return &json.UnmarshalTypeError{
+			Value:  "number " + e.Value,
+			Type:   e.Type,
+			Offset: int64(e.Offset),
+		}
 	}
 	return err
 }
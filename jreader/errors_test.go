@@ -43,6 +43,11 @@ func TestTypeError(t *testing.T) {
 		TypeError{Expected: NullValue, Actual: 99, Offset: 2}.Error())
 }
 
+func TestRangeError(t *testing.T) {
+	assert.Equal(t, "number 300 cannot be represented as Go type int8 at position 2",
+		RangeError{Value: "300", Type: reflect.TypeOf(int8(0)), Offset: 2}.Error())
+}
+
 func TestToJSONError(t *testing.T) {
 	e1 := SyntaxError{Message: "xyz", Offset: 2}
 	je1 := ToJSONError(e1, nil)
@@ -53,6 +58,10 @@ func TestToJSONError(t *testing.T) {
 	je2 := ToJSONError(e2, someIntValue)
 	assert.Equal(t, &json.UnmarshalTypeError{Value: "number", Offset: 2, Type: reflect.TypeOf(someIntValue)}, je2)
 
-	e3 := errors.New("some other error")
-	assert.Equal(t, e3, ToJSONError(e3, nil))
+	e3 := RangeError{Value: "300", Type: reflect.TypeOf(int8(0)), Offset: 2}
+	je3 := ToJSONError(e3, nil)
+	assert.Equal(t, &json.UnmarshalTypeError{Value: "number 300", Offset: 2, Type: reflect.TypeOf(int8(0))}, je3)
+
+	e4 := errors.New("some other error")
+	assert.Equal(t, e4, ToJSONError(e4, nil))
 }
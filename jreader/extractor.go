@@ -0,0 +1,318 @@
+package jreader
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jpointer"
+)
+
+// Extractor finds values at a set of paths within a JSON document in a single pass, calling a
+// function for each value that it finds. It is more efficient than using Reader.Seek repeatedly,
+// since it does not need to rescan the input for each path.
+//
+// An Extractor is created from a list of patterns with NewExtractor. It is immutable, so it can be
+// reused, and used from multiple goroutines at once.
+//
+//	extractor, err := jreader.NewExtractor("/name", "/items/*/key", "$.tags[*]")
+//	// handle err
+//	r := jreader.NewReader(data)
+//	extractor.Extract(&r, func(pattern int, path jpointer.Pointer, r *jreader.Reader) {
+//	    switch pattern {
+//	    case 0:
+//	        name = r.String()
+//	    case 1:
+//	        keys = append(keys, r.String())
+//	    case 2:
+//	        tags = append(tags, r.String())
+//	    }
+//	})
+//	if err := r.Error(); err != nil {
+//	    // the data was malformed, or one of the values was not of the expected type
+//	}
+type Extractor struct {
+	root *extractorNode
+}
+
+// ExtractorFunc is the type of the function that Extractor.Extract calls for each value that
+// matches a pattern. The pattern parameter is the index of the pattern in the list that was passed
+// to NewExtractor, and path is the location of the value within the document.
+//
+// The function may use the Reader to read the value, using any method that consumes exactly one
+// value. If it does not read the value, the value is skipped. It should not do anything else with
+// the Reader.
+type ExtractorFunc func(pattern int, path jpointer.Pointer, r *Reader)
+
+type extractorNode struct {
+	matches  []int
+	names    map[string]*extractorNode
+	indexes  map[int]*extractorNode
+	wildcard *extractorNode
+}
+
+type extractorToken struct {
+	name         string
+	index        int
+	matchesName  bool
+	matchesIndex bool
+	wildcard     bool
+}
+
+type extractorPathElement struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+// NewExtractor creates an Extractor from a list of patterns. Each pattern can be in either of
+// two forms:
+//
+// - A JSON Pointer (see the jpointer package), such as "/items/0/key". In a pattern, the reference
+// token "*" is a wildcard that matches any array element or object property.
+//
+// - A simple JSONPath expression, consisting of "$" followed by any number of the selectors
+// ".name", "['name']", "[index]", ".*", and "[*]", such as "$.items[0].key" or "$.items[*]['key']".
+// Filters, slices, and recursive descent are not supported.
+//
+// A value can match more than one pattern, and patterns can refer to values that are nested inside
+// other values that match a pattern; in either of those cases, the value is buffered so that it can
+// be read more than once, and when it is passed to the ExtractorFunc, any error offsets reported
+// by the Reader are relative to the start of the value. If a value matches more than one pattern,
+// the ExtractorFunc is called for each of them in the order that the patterns were given.
+//
+// If a pattern is not valid, NewExtractor returns nil and an error.
+func NewExtractor(patterns ...string) (*Extractor, error) {
+	root := &extractorNode{}
+	for i, pattern := range patterns {
+		tokens, err := parseExtractorPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		// A pointer token like "0" can refer to either an array index or a property name, so a
+		// pattern can lead to more than one node.
+		nodes := []*extractorNode{root}
+		for _, token := range tokens {
+			var next []*extractorNode
+			for _, node := range nodes {
+				next = node.addChildren(token, next)
+			}
+			nodes = next
+		}
+		for _, node := range nodes {
+			node.matches = append(node.matches, i)
+		}
+	}
+	return &Extractor{root: root}, nil
+}
+
+func (n *extractorNode) addChildren(token extractorToken, nodes []*extractorNode) []*extractorNode {
+	if token.wildcard {
+		if n.wildcard == nil {
+			n.wildcard = &extractorNode{}
+		}
+		return append(nodes, n.wildcard)
+	}
+	if token.matchesName {
+		child := n.names[token.name]
+		if child == nil {
+			child = &extractorNode{}
+			if n.names == nil {
+				n.names = make(map[string]*extractorNode)
+			}
+			n.names[token.name] = child
+		}
+		nodes = append(nodes, child)
+	}
+	if token.matchesIndex {
+		child := n.indexes[token.index]
+		if child == nil {
+			child = &extractorNode{}
+			if n.indexes == nil {
+				n.indexes = make(map[int]*extractorNode)
+			}
+			n.indexes[token.index] = child
+		}
+		nodes = append(nodes, child)
+	}
+	return nodes
+}
+
+func (n *extractorNode) hasChildren() bool {
+	return len(n.names) != 0 || len(n.indexes) != 0 || n.wildcard != nil
+}
+
+// Extract reads the next JSON value from the Reader, calling fn for each value within it that
+// matches one of the Extractor's patterns. Values are visited in the order that they appear in the
+// input. All other values are skipped.
+//
+// If there is a parsing error, or if fn causes an error, the Reader enters a failed state, which
+// you can detect with Error(), and no more values are visited.
+func (e *Extractor) Extract(r *Reader, fn ExtractorFunc) {
+	e.visit(r, []*extractorNode{e.root}, nil, fn)
+}
+
+func (e *Extractor) visit(r *Reader, nodes []*extractorNode, path []extractorPathElement, fn ExtractorFunc) {
+	var matches []int
+	hasChildren := false
+	for _, node := range nodes {
+		matches = append(matches, node.matches...)
+		hasChildren = hasChildren || node.hasChildren()
+	}
+	sort.Ints(matches)
+	switch {
+	case len(matches) == 0 && !hasChildren:
+		_ = r.SkipValue()
+	case len(matches) == 0:
+		e.visitChildren(r, nodes, path, fn)
+	case len(matches) == 1 && !hasChildren:
+		r.awaitingReadValue = true
+		fn(matches[0], pointerForPath(path), r)
+		if r.awaitingReadValue {
+			_ = r.SkipValue()
+		}
+	default:
+		// The value needs to be read more than once, so we'll buffer it.
+		raw := r.RawValue()
+		if r.err != nil {
+			return
+		}
+		pointer := pointerForPath(path)
+		for _, pattern := range matches {
+			sub := NewReader(raw)
+			fn(pattern, pointer, &sub)
+			if sub.err != nil {
+				r.err = sub.err
+				return
+			}
+		}
+		if hasChildren {
+			sub := NewReader(raw)
+			e.visitChildren(&sub, nodes, path, fn)
+			r.AddError(sub.err)
+		}
+	}
+}
+
+func (e *Extractor) visitChildren(r *Reader, nodes []*extractorNode, path []extractorPathElement, fn ExtractorFunc) {
+	value := r.any(true)
+	var children []*extractorNode
+	switch value.Kind {
+	case ArrayValue:
+		for i := 0; value.Array.Next(); i++ {
+			children = children[:0]
+			for _, node := range nodes {
+				if child := node.indexes[i]; child != nil {
+					children = append(children, child)
+				}
+				if node.wildcard != nil {
+					children = append(children, node.wildcard)
+				}
+			}
+			if len(children) != 0 {
+				e.visit(r, children, append(path, extractorPathElement{index: i, isIndex: true}), fn)
+			}
+		}
+	case ObjectValue:
+		for value.Object.Next() {
+			name := string(value.Object.Name())
+			children = children[:0]
+			for _, node := range nodes {
+				if child := node.names[name]; child != nil {
+					children = append(children, child)
+				}
+				if node.wildcard != nil {
+					children = append(children, node.wildcard)
+				}
+			}
+			if len(children) != 0 {
+				e.visit(r, children, append(path, extractorPathElement{name: name}), fn)
+			}
+		}
+	}
+}
+
+func pointerForPath(path []extractorPathElement) jpointer.Pointer {
+	tokens := make([]string, len(path))
+	for i, element := range path {
+		if element.isIndex {
+			tokens[i] = strconv.Itoa(element.index)
+		} else {
+			tokens[i] = element.name
+		}
+	}
+	return jpointer.New(tokens...)
+}
+
+func parseExtractorPattern(pattern string) ([]extractorToken, error) {
+	if strings.HasPrefix(pattern, "$") {
+		return parseExtractorPath(pattern)
+	}
+	pointer, err := jpointer.Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]extractorToken, pointer.Len())
+	for i := range tokens {
+		name := pointer.Token(i)
+		if name == "*" {
+			tokens[i] = extractorToken{wildcard: true}
+			continue
+		}
+		index, isIndex := jpointer.ArrayIndex(name)
+		tokens[i] = extractorToken{name: name, index: index, matchesName: true, matchesIndex: isIndex}
+	}
+	return tokens, nil
+}
+
+func parseExtractorPath(pattern string) ([]extractorToken, error) {
+	var tokens []extractorToken
+	pos := 1
+	fail := func(message string) ([]extractorToken, error) {
+		return nil, fmt.Errorf("invalid JSONPath pattern %q at offset %d: %s", pattern, pos, message)
+	}
+	for pos < len(pattern) {
+		switch pattern[pos] {
+		case '.':
+			pos++
+			if pos < len(pattern) && pattern[pos] == '*' {
+				tokens = append(tokens, extractorToken{wildcard: true})
+				pos++
+				continue
+			}
+			start := pos
+			for pos < len(pattern) && pattern[pos] != '.' && pattern[pos] != '[' {
+				pos++
+			}
+			if pos == start {
+				return fail("expected property name")
+			}
+			tokens = append(tokens, extractorToken{name: pattern[start:pos], matchesName: true})
+		case '[':
+			pos++
+			end := strings.IndexByte(pattern[pos:], ']')
+			if end < 0 {
+				return fail("missing ']'")
+			}
+			selector := pattern[pos : pos+end]
+			switch {
+			case selector == "*":
+				tokens = append(tokens, extractorToken{wildcard: true})
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') &&
+				selector[len(selector)-1] == selector[0]:
+				tokens = append(tokens, extractorToken{name: selector[1 : len(selector)-1], matchesName: true})
+			default:
+				index, ok := jpointer.ArrayIndex(selector)
+				if !ok {
+					return fail("expected array index, quoted property name, or '*'")
+				}
+				tokens = append(tokens, extractorToken{index: index, matchesIndex: true})
+			}
+			pos += end + 1
+		default:
+			return fail("expected '.' or '['")
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,212 @@
+package jreader
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jpointer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const extractorTestData = `{
+	"name": "x",
+	"items": [{"key": "a", "n": 1}, {"key": "b", "n": 2}, {"n": 3}],
+	"tags": ["t1", "t2"],
+	"skipped": {"x": [1, 2, {"y": null}]},
+	"0": "zero"
+}`
+
+type extractedValue struct {
+	pattern int
+	path    string
+	value   interface{}
+}
+
+func extractAll(t *testing.T, data string, patterns ...string) ([]extractedValue, error) {
+	e, err := NewExtractor(patterns...)
+	require.NoError(t, err)
+	var ret []extractedValue
+	r := NewReader([]byte(data))
+	e.Extract(&r, func(pattern int, path jpointer.Pointer, r *Reader) {
+		ret = append(ret, extractedValue{pattern, path.String(), r.ReadInterface()})
+	})
+	return ret, r.Error()
+}
+
+func TestRawValue(t *testing.T) {
+	for _, s := range []string{
+		`null`, `true`, `1.5e3`, `"a\"b"`, `[]`, `[1, [2, 3], {"a": "b"}]`, `{"a": {"b": [null]}}`,
+	} {
+		t.Run(s, func(t *testing.T) {
+			r := NewReader([]byte(`[ ` + s + ` , 2]`))
+			arr := r.Array()
+			require.True(t, arr.Next())
+			assert.Equal(t, s, string(r.RawValue()))
+			require.True(t, arr.Next())
+			assert.Equal(t, 2, r.Int())
+			assert.False(t, arr.Next())
+			assert.NoError(t, r.Error())
+		})
+	}
+
+	t.Run("syntax error", func(t *testing.T) {
+		r := NewReader([]byte(`{"a": [1, {"b": x}]}`))
+		assert.Nil(t, r.RawValue())
+		assert.Error(t, r.Error())
+	})
+
+	t.Run("reader already failed", func(t *testing.T) {
+		r := NewReader([]byte(`1`))
+		r.AddError(SyntaxError{})
+		assert.Nil(t, r.RawValue())
+	})
+}
+
+func TestExtractor(t *testing.T) {
+	t.Run("single patterns", func(t *testing.T) {
+		for _, p := range []struct {
+			pattern  string
+			expected []extractedValue
+		}{
+			{`/name`, []extractedValue{{0, "/name", "x"}}},
+			{`$.name`, []extractedValue{{0, "/name", "x"}}},
+			{`$['name']`, []extractedValue{{0, "/name", "x"}}},
+			{`$["name"]`, []extractedValue{{0, "/name", "x"}}},
+			{`/items/1/key`, []extractedValue{{0, "/items/1/key", "b"}}},
+			{`$.items[1].key`, []extractedValue{{0, "/items/1/key", "b"}}},
+			{`/items/*/key`, []extractedValue{{0, "/items/0/key", "a"}, {0, "/items/1/key", "b"}}},
+			{`$.items[*].key`, []extractedValue{{0, "/items/0/key", "a"}, {0, "/items/1/key", "b"}}},
+			{`$.tags.*`, []extractedValue{{0, "/tags/0", "t1"}, {0, "/tags/1", "t2"}}},
+			{`/0`, []extractedValue{{0, "/0", "zero"}}},
+			{`$.0`, []extractedValue{{0, "/0", "zero"}}},
+			{`$[0]`, nil},
+			{`/tags/01`, nil},
+			{`/missing`, nil},
+			{`/name/x`, nil},
+			{``, []extractedValue{{0, "", nil}}},
+		} {
+			t.Run(p.pattern, func(t *testing.T) {
+				values, err := extractAll(t, extractorTestData, p.pattern)
+				require.NoError(t, err)
+				if p.pattern == `` {
+					require.Len(t, values, 1)
+					assert.Equal(t, "", values[0].path)
+					return
+				}
+				assert.Equal(t, p.expected, values)
+			})
+		}
+	})
+
+	t.Run("multiple patterns are visited in document order", func(t *testing.T) {
+		values, err := extractAll(t, extractorTestData, "$.tags[0]", "/items/*/n", "/name")
+		require.NoError(t, err)
+		assert.Equal(t, []extractedValue{
+			{2, "/name", "x"},
+			{1, "/items/0/n", float64(1)},
+			{1, "/items/1/n", float64(2)},
+			{1, "/items/2/n", float64(3)},
+			{0, "/tags/0", "t1"},
+		}, values)
+	})
+
+	t.Run("same value matches more than one pattern", func(t *testing.T) {
+		values, err := extractAll(t, extractorTestData, "/items/0/key", "$.items[*].key", "/items/0/key")
+		require.NoError(t, err)
+		assert.Equal(t, []extractedValue{
+			{0, "/items/0/key", "a"},
+			{1, "/items/0/key", "a"},
+			{2, "/items/0/key", "a"},
+			{1, "/items/1/key", "b"},
+		}, values)
+	})
+
+	t.Run("pointer and JSONPath forms of a numeric token are combined", func(t *testing.T) {
+		values, err := extractAll(t, `{"a": [10, 11], "b": {"0": 20}}`, "$.*[0]", "/*/0", "$.*.0")
+		require.NoError(t, err)
+		assert.Equal(t, []extractedValue{
+			{0, "/a/0", float64(10)},
+			{1, "/a/0", float64(10)},
+			{1, "/b/0", float64(20)},
+			{2, "/b/0", float64(20)},
+		}, values)
+	})
+
+	t.Run("pattern nested inside another match", func(t *testing.T) {
+		values, err := extractAll(t, extractorTestData, "/items/1", "/items/1/key", "/tags")
+		require.NoError(t, err)
+		assert.Equal(t, []extractedValue{
+			{0, "/items/1", map[string]interface{}{"key": "b", "n": float64(2)}},
+			{1, "/items/1/key", "b"},
+			{2, "/tags", []interface{}{"t1", "t2"}},
+		}, values)
+	})
+
+	t.Run("value not read by callback is skipped", func(t *testing.T) {
+		e, err := NewExtractor("/items", "/tags/1")
+		require.NoError(t, err)
+		var tag string
+		r := NewReader([]byte(extractorTestData))
+		e.Extract(&r, func(pattern int, path jpointer.Pointer, r *Reader) {
+			if pattern == 1 {
+				tag = r.String()
+			}
+		})
+		assert.NoError(t, r.Error())
+		assert.Equal(t, "t2", tag)
+		assert.NoError(t, r.RequireEOF())
+	})
+
+	t.Run("type error in callback", func(t *testing.T) {
+		e, err := NewExtractor("/name", "/tags/0")
+		require.NoError(t, err)
+		calls := 0
+		r := NewReader([]byte(extractorTestData))
+		e.Extract(&r, func(pattern int, path jpointer.Pointer, r *Reader) {
+			calls++
+			_ = r.Int()
+		})
+		assert.IsType(t, TypeError{}, r.Error())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("type error in callback for buffered value", func(t *testing.T) {
+		e, err := NewExtractor("/name", "/name")
+		require.NoError(t, err)
+		calls := 0
+		r := NewReader([]byte(extractorTestData))
+		e.Extract(&r, func(pattern int, path jpointer.Pointer, r *Reader) {
+			calls++
+			_ = r.Int()
+		})
+		assert.IsType(t, TypeError{}, r.Error())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		for _, data := range []string{
+			`{"a": x, "name": "x"}`,
+			`{"skipped": [1, [x]], "name": "x"}`,
+			`{"tags": [1, [x]], "name": "x"}`,
+		} {
+			t.Run(data, func(t *testing.T) {
+				values, err := extractAll(t, data, "/name", "/tags", "/tags/0")
+				assert.Error(t, err)
+				assert.Len(t, values, 0)
+			})
+		}
+	})
+
+	t.Run("invalid patterns", func(t *testing.T) {
+		for _, pattern := range []string{
+			`a`, `/a~2`, `$a`, `$.`, `$.a..b`, `$[`, `$[x]`, `$[-1]`, `$['a]`, `$[01]`,
+		} {
+			t.Run(pattern, func(t *testing.T) {
+				e, err := NewExtractor("/name", pattern)
+				assert.Error(t, err)
+				assert.Nil(t, e)
+			})
+		}
+	})
+}
@@ -1,5 +1,11 @@
 package jreader
 
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
 // Reader is a high-level API for reading JSON data sequentially.
 //
 // It is designed to make writing custom unmarshallers for application types as convenient as
@@ -170,6 +176,65 @@ func (r *Reader) Float64OrNull() (float64, bool) {
 	return val, true
 }
 
+// Float32 attempts to read a numeric value and returns it as a float32. The number is converted
+// from its original text, so the result is the closest float32 to that value, rather than the
+// float32 closest to the float64 that Float64 would return.
+//
+// If there is a parsing error, or the next value is not a number, the return value is zero and
+// the Reader enters a failed state, which you can detect with Error(). If the number is too large
+// to be represented as a float32, the return value is zero and the error is a RangeError.
+func (r *Reader) Float32() float32 {
+	literal := r.JSONNumber()
+	if r.err != nil {
+		return 0
+	}
+	val, err := strconv.ParseFloat(string(literal), 32)
+	if err != nil {
+		r.err = RangeError{Value: string(literal), Type: reflect.TypeOf(float32(0)), Offset: r.tr.LastPos()}
+		return 0
+	}
+	return float32(val)
+}
+
+// Float32OrNull attempts to read either a numeric value or a null. In the case of a number, the
+// return values are (value, true); for a null, they are (0, false).
+//
+// If there is a parsing error, or the next value is neither a number nor a null, the return values
+// are (0, false) and the Reader enters a failed state, which you can detect with Error(). The
+// number is converted in the same way as by Float32.
+func (r *Reader) Float32OrNull() (float32, bool) {
+	r.awaitingReadValue = false
+	if r.err != nil {
+		return 0, false
+	}
+	isNull, err := r.tr.Null()
+	if isNull || err != nil {
+		r.err = err
+		return 0, false
+	}
+	val := r.Float32()
+	if r.err != nil {
+		r.err = typeErrorForNullableValue(r.err)
+		return 0, false
+	}
+	return val, true
+}
+
+// JSONNumber attempts to read a numeric value and returns it as a json.Number, which is the
+// number's literal text exactly as it appeared in the input. Unlike Float64, this does not lose
+// precision for integers that are too large to be represented exactly as a float64.
+//
+// If there is a parsing error, or the next value is not a number, the return value is an empty
+// string and the Reader enters a failed state, which you can detect with Error().
+func (r *Reader) JSONNumber() json.Number {
+	v, literal := r.AnyWithNumberLiteral()
+	if r.err == nil && v.Kind != NumberValue {
+		r.err = TypeError{Expected: NumberValue, Actual: v.Kind, Offset: r.tr.LastPos()}
+		return ""
+	}
+	return literal
+}
+
 // StringOrNull attempts to read either a string value or a null. In the case of a string, the
 // return values are (value, true); for a null, they are ("", false).
 //
@@ -330,6 +395,27 @@ func (r *Reader) any(ignoreString bool) AnyValue {
 		r.err = err
 		return AnyValue{}
 	}
+	return r.anyValueForToken(v)
+}
+
+// AnyWithNumberLiteral is the same as Any, except that if the value is a number, it also returns
+// the number's literal text exactly as it appeared in the input, as a json.Number. This allows
+// numbers to be reproduced without any loss of precision or change of format. For any other kind
+// of value, the json.Number is empty.
+func (r *Reader) AnyWithNumberLiteral() (AnyValue, json.Number) {
+	r.awaitingReadValue = false
+	if r.err != nil {
+		return AnyValue{}, ""
+	}
+	v, literal, err := r.tr.AnyWithNumberLiteral()
+	if err != nil {
+		r.err = err
+		return AnyValue{}, ""
+	}
+	return r.anyValueForToken(v), json.Number(literal)
+}
+
+func (r *Reader) anyValueForToken(v AnyValue) AnyValue {
 	switch v.Kind {
 	case BoolValue:
 		return AnyValue{Kind: v.Kind, Bool: v.Bool}
@@ -37,7 +37,11 @@ func (arr *ArrayState) IsDefined() bool {
 //
 // See ArrayState for example code.
 func (arr *ArrayState) Next() bool {
-	if arr.r == nil || arr.r.err != nil {
+	if arr.r == nil {
+		return false
+	}
+	if arr.r.err != nil {
+		arr.r.awaitingReadValue = false // see Reader.StringReader
 		return false
 	}
 	var isEnd bool
@@ -0,0 +1,126 @@
+package jreader
+
+// ReadablePointer is a constraint for use with ReadReadable. It describes a pointer type *T that
+// implements Readable, so that generic code can allocate a T and then call ReadFromJSONReader on it.
+type ReadablePointer[T any] interface {
+	*T
+	Readable
+}
+
+// ReadSlice reads a JSON array, calling readElement to read each element, and returns the elements
+// as a slice. A JSON null is accepted and produces a nil slice; an empty JSON array produces an
+// empty non-nil slice.
+//
+// The readElement function should consume exactly one JSON value using the Reader's methods. It can
+// be a method expression such as (*Reader).String, a function like ReadReadable, or a closure.
+//
+//	names := jreader.ReadSlice(r, (*jreader.Reader).String)
+//	items := jreader.ReadSlice(r, jreader.ReadReadable[MyItem])
+//
+// If there is a parsing error, or the value is neither an array nor a null, or readElement causes
+// an error, the return value is nil and the Reader enters a failed state, which you can detect with
+// Error().
+func ReadSlice[T any](r *Reader, readElement func(*Reader) T) []T {
+	arr := r.ArrayOrNull()
+	if !arr.IsDefined() {
+		return nil
+	}
+	ret := AppendSlice(r, &arr, []T{}, readElement)
+	if r.err != nil {
+		return nil
+	}
+	return ret
+}
+
+// AppendSlice is a variant of ReadSlice that reads the remaining elements of an array that has
+// already been started with Array or ArrayOrNull, and appends them to an existing slice. This can
+// be used to avoid allocating a new slice when one is being reused.
+//
+//	values = jreader.AppendSlice(r, &arr, values[:0], (*jreader.Reader).Int)
+//
+// If there is an error, the return value is the same slice that was passed in, and the Reader
+// enters a failed state, which you can detect with Error().
+func AppendSlice[T any](r *Reader, arr *ArrayState, dest []T, readElement func(*Reader) T) []T {
+	originalLen := len(dest)
+	for arr.Next() {
+		value := readElement(r)
+		if r.err != nil {
+			break
+		}
+		dest = append(dest, value)
+	}
+	if r.err != nil {
+		return dest[:originalLen]
+	}
+	return dest
+}
+
+// ReadMap reads a JSON object, calling readValue to read each property value, and returns the
+// properties as a map. A JSON null is accepted and produces a nil map; an empty JSON object produces
+// an empty non-nil map. If a property name appears more than once, the last value wins.
+//
+// The readValue function should consume exactly one JSON value using the Reader's methods. It can
+// be a method expression such as (*Reader).Int, a function like ReadReadable, or a closure.
+//
+// If there is a parsing error, or the value is neither an object nor a null, or readValue causes an
+// error, the return value is nil and the Reader enters a failed state, which you can detect with
+// Error().
+func ReadMap[T any](r *Reader, readValue func(*Reader) T) map[string]T {
+	obj := r.ObjectOrNull()
+	if !obj.IsDefined() {
+		return nil
+	}
+	ret := make(map[string]T)
+	for obj.Next() {
+		name := obj.Name()
+		value := readValue(r)
+		if r.err != nil {
+			return nil
+		}
+		ret[string(name)] = value
+	}
+	if r.err != nil {
+		return nil
+	}
+	return ret
+}
+
+// ReadOptional reads either a null or a value of some other type. In the case of a null, the
+// return values are (zero value, false). Otherwise, it calls readValue to read the value and returns
+// (value, true).
+//
+// This is a generalization of nullable methods such as StringOrNull. As with those methods, if the
+// value is not of the expected type, the resulting TypeError will indicate that null was also
+// acceptable.
+//
+// If there is a parsing error, or readValue causes an error, the return values are (zero value,
+// false) and the Reader enters a failed state, which you can detect with Error().
+func ReadOptional[T any](r *Reader, readValue func(*Reader) T) (T, bool) {
+	var empty T
+	r.awaitingReadValue = false
+	if r.err != nil {
+		return empty, false
+	}
+	isNull, err := r.tr.Null()
+	if isNull || err != nil {
+		r.err = err
+		return empty, false
+	}
+	value := readValue(r)
+	if r.err != nil {
+		r.err = typeErrorForNullableValue(r.err)
+		return empty, false
+	}
+	return value, true
+}
+
+// ReadReadable reads a value of type T, where *T implements Readable, by calling its
+// ReadFromJSONReader method. It is meant to be passed as a parameter to ReadSlice, ReadMap, or
+// ReadOptional; the type parameter for the pointer type is normally inferred:
+//
+//	items := jreader.ReadSlice(r, jreader.ReadReadable[MyItem])
+func ReadReadable[T any, PT ReadablePointer[T]](r *Reader) T {
+	var value T
+	PT(&value).ReadFromJSONReader(r)
+	return value
+}
@@ -0,0 +1,164 @@
+package jreader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type readableItem struct {
+	key   string
+	count int
+}
+
+func (item *readableItem) ReadFromJSONReader(r *Reader) {
+	for obj := r.Object(); obj.Next(); {
+		switch string(obj.Name()) {
+		case "key":
+			item.key = r.String()
+		case "count":
+			item.count = r.Int()
+		}
+	}
+}
+
+func TestReadSlice(t *testing.T) {
+	t.Run("scalars", func(t *testing.T) {
+		r := NewReader([]byte(`["a", "b", "c"]`))
+		values := ReadSlice(&r, (*Reader).String)
+		require.NoError(t, r.Error())
+		assert.Equal(t, []string{"a", "b", "c"}, values)
+	})
+
+	t.Run("readables", func(t *testing.T) {
+		r := NewReader([]byte(`[{"key":"a","count":1},{"key":"b","count":2}]`))
+		values := ReadSlice(&r, ReadReadable[readableItem])
+		require.NoError(t, r.Error())
+		assert.Equal(t, []readableItem{{"a", 1}, {"b", 2}}, values)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		r := NewReader([]byte(`[]`))
+		values := ReadSlice(&r, (*Reader).Int)
+		require.NoError(t, r.Error())
+		assert.NotNil(t, values)
+		assert.Len(t, values, 0)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		r := NewReader([]byte(`null`))
+		values := ReadSlice(&r, (*Reader).Int)
+		require.NoError(t, r.Error())
+		assert.Nil(t, values)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		r := NewReader([]byte(`{}`))
+		values := ReadSlice(&r, (*Reader).Int)
+		assert.Nil(t, values)
+		require.IsType(t, TypeError{}, r.Error())
+		assert.Equal(t, ArrayValue, r.Error().(TypeError).Expected)
+		assert.True(t, r.Error().(TypeError).Nullable)
+	})
+
+	t.Run("element error", func(t *testing.T) {
+		r := NewReader([]byte(`[1, "x", 3]`))
+		values := ReadSlice(&r, (*Reader).Int)
+		assert.Nil(t, values)
+		require.IsType(t, TypeError{}, r.Error())
+		assert.Equal(t, NumberValue, r.Error().(TypeError).Expected)
+	})
+}
+
+func TestAppendSlice(t *testing.T) {
+	buf := make([]int, 0, 10)
+	buf = append(buf, 99)
+
+	r := NewReader([]byte(`[1, 2]`))
+	arr := r.Array()
+	values := AppendSlice(&r, &arr, buf, (*Reader).Int)
+	require.NoError(t, r.Error())
+	assert.Equal(t, []int{99, 1, 2}, values)
+	assert.Equal(t, &buf[0:1][0], &values[0]) // reused the same backing array
+
+	r = NewReader([]byte(`[1, true]`))
+	arr = r.Array()
+	values = AppendSlice(&r, &arr, buf, (*Reader).Int)
+	assert.Error(t, r.Error())
+	assert.Equal(t, []int{99}, values)
+}
+
+func TestReadMap(t *testing.T) {
+	t.Run("scalars", func(t *testing.T) {
+		r := NewReader([]byte(`{"a": 1, "b": 2, "a": 3}`))
+		values := ReadMap(&r, (*Reader).Int)
+		require.NoError(t, r.Error())
+		assert.Equal(t, map[string]int{"a": 3, "b": 2}, values)
+	})
+
+	t.Run("readables", func(t *testing.T) {
+		r := NewReader([]byte(`{"x": {"key":"a","count":1}}`))
+		values := ReadMap(&r, ReadReadable[readableItem])
+		require.NoError(t, r.Error())
+		assert.Equal(t, map[string]readableItem{"x": {"a", 1}}, values)
+	})
+
+	t.Run("empty object", func(t *testing.T) {
+		r := NewReader([]byte(`{}`))
+		values := ReadMap(&r, (*Reader).Int)
+		require.NoError(t, r.Error())
+		assert.NotNil(t, values)
+		assert.Len(t, values, 0)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		r := NewReader([]byte(`null`))
+		values := ReadMap(&r, (*Reader).Int)
+		require.NoError(t, r.Error())
+		assert.Nil(t, values)
+	})
+
+	t.Run("value error", func(t *testing.T) {
+		r := NewReader([]byte(`{"a": 1, "b": false}`))
+		values := ReadMap(&r, (*Reader).Int)
+		assert.Nil(t, values)
+		require.IsType(t, TypeError{}, r.Error())
+	})
+}
+
+func TestReadOptional(t *testing.T) {
+	r := NewReader([]byte(`[null, "a", ["b"], 3]`))
+	arr := r.Array()
+
+	require.True(t, arr.Next())
+	value, ok := ReadOptional(&r, (*Reader).String)
+	require.NoError(t, r.Error())
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+
+	require.True(t, arr.Next())
+	value, ok = ReadOptional(&r, (*Reader).String)
+	require.NoError(t, r.Error())
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+
+	require.True(t, arr.Next())
+	values, ok := ReadOptional(&r, func(r *Reader) []string { return ReadSlice(r, (*Reader).String) })
+	require.NoError(t, r.Error())
+	assert.True(t, ok)
+	assert.Equal(t, []string{"b"}, values)
+
+	require.True(t, arr.Next())
+	value, ok = ReadOptional(&r, (*Reader).String)
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+	require.IsType(t, TypeError{}, r.Error())
+	te := r.Error().(TypeError)
+	assert.Equal(t, StringValue, te.Expected)
+	assert.True(t, te.Nullable)
+
+	value, ok = ReadOptional(&r, (*Reader).String)
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+}
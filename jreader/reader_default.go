@@ -3,6 +3,8 @@
 
 package jreader
 
+import "encoding/json"
+
 // String attempts to read a string value.
 //
 // If there is a parsing error, or the next value is not a string, the return value is "" and
@@ -31,3 +33,31 @@ func (r *Reader) StringAsBytes() []byte {
 	}
 	return val
 }
+
+// RawValue consumes the next JSON value of any type, including all nested values within an array
+// or object, and returns its exact text as it appeared in the input (not including any surrounding
+// whitespace). The value is checked for validity in the same way as SkipValue.
+//
+// The returned slice refers to the original JSON bytes, so care must be taken to avoid modifying it.
+//
+// If there is a parsing error, the return value is nil and the Reader enters a failed state, which
+// you can detect with Error().
+func (r *Reader) RawValue() json.RawMessage {
+	r.awaitingReadValue = false
+	if r.err != nil {
+		return nil
+	}
+	v := r.any(true)
+	start := r.tr.LastPos()
+	if v.Kind == ArrayValue {
+		for v.Array.Next() {
+		}
+	} else if v.Kind == ObjectValue {
+		for v.Object.Next() {
+		}
+	}
+	if r.err != nil {
+		return nil
+	}
+	return r.tr.data[start:r.tr.getPos()]
+}
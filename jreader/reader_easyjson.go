@@ -3,6 +3,8 @@
 
 package jreader
 
+import "encoding/json"
+
 // String attempts to read a string value.
 //
 // If there is a parsing error, or the next value is not a string, the return value is "" and
@@ -29,3 +31,22 @@ func (r *Reader) String() string {
 func (r *Reader) StringAsBytes() []byte {
 	return []byte(r.String())
 }
+
+// RawValue consumes the next JSON value of any type, including all nested values within an array
+// or object, and returns its exact text as it appeared in the input (not including any surrounding
+// whitespace). The value is checked for validity in the same way as SkipValue.
+//
+// If there is a parsing error, the return value is nil and the Reader enters a failed state, which
+// you can detect with Error().
+func (r *Reader) RawValue() json.RawMessage {
+	r.awaitingReadValue = false
+	if r.err != nil {
+		return nil
+	}
+	raw, err := r.tr.RawValue()
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	return raw
+}
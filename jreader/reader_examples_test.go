@@ -1,6 +1,12 @@
 package jreader
 
-import "fmt"
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jpointer"
+)
 
 func ExampleNewReader() {
 	r := NewReader([]byte(`"a \"good\" string"`))
@@ -250,3 +256,85 @@ func ExampleObjectState_WithRequiredProperties() {
 	}
 	// Output: missing property: key
 }
+
+func ExampleReadSlice() {
+	r := NewReader([]byte(`["a","b","c"]`))
+	values := ReadSlice(&r, (*Reader).String)
+	fmt.Println(values, r.Error())
+	// Output: [a b c] <nil>
+}
+
+func ExampleReadMap() {
+	r := NewReader([]byte(`{"a":1,"b":2}`))
+	values := ReadMap(&r, (*Reader).Int)
+	fmt.Println(values["a"], values["b"], r.Error())
+	// Output: 1 2 <nil>
+}
+
+func ExampleReadOptional() {
+	r := NewReader([]byte(`[null,[1,2]]`))
+	for arr := r.Array(); arr.Next(); {
+		values, nonNull := ReadOptional(&r, func(r *Reader) []int { return ReadSlice(r, (*Reader).Int) })
+		fmt.Println(values, nonNull)
+	}
+	// Output: [] false
+	// [1 2] true
+}
+
+func ExampleReadInto() {
+	var value struct {
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Count *int     `json:"count"`
+	}
+	r := NewReader([]byte(`{"name":"x","tags":["a","b"],"count":null}`))
+	ReadInto(&r, &value)
+	fmt.Println(value.Name, value.Tags, value.Count, r.Error())
+	// Output: x [a b] <nil> <nil>
+}
+
+func ExampleReader_ReadInterface() {
+	r := NewReader([]byte(`{"a": [1, true, null], "b": {"c": "d"}}`))
+	value := r.ReadInterface()
+	fmt.Println(value, r.Error())
+	// Output: map[a:[1 true <nil>] b:map[c:d]] <nil>
+}
+
+func ExampleFind() {
+	data := []byte(`{"items": [{"key": "a"}, {"key": "b"}], "other": [1, 2, 3]}`)
+	r, found := Find(data, "/items/1/key")
+	fmt.Println(found, r.String(), r.Error())
+	// Output: true b <nil>
+}
+
+func ExampleExtractor() {
+	extractor, err := NewExtractor("/name", "$.items[*].key")
+	if err != nil {
+		panic(err)
+	}
+	data := []byte(`{"items": [{"key": "a"}, {"key": "b"}], "name": "x"}`)
+	r := NewReader(data)
+	extractor.Extract(&r, func(pattern int, path jpointer.Pointer, r *Reader) {
+		fmt.Println(pattern, path, r.String())
+	})
+	fmt.Println(r.Error())
+	// Output: 1 /items/0/key a
+	// 1 /items/1/key b
+	// 0 /name x
+	// <nil>
+}
+
+func ExampleReader_StringReader() {
+	r := NewReader([]byte(`{"name": "upload", "data": "aGVsbG8sIHdvcmxk"}`))
+	for obj := r.Object(); obj.Next(); {
+		switch string(obj.Name()) {
+		case "data":
+			decoded, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, r.StringReader()))
+			fmt.Println(string(decoded))
+		}
+	}
+	if err := r.Error(); err != nil {
+		fmt.Println("error:", err)
+	}
+	// Output: hello, world
+}
@@ -0,0 +1,77 @@
+package jreader
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Integer is a constraint for use with ReadInteger. It describes any Go integer type, including
+// named types whose underlying type is an integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// ReadInteger reads a numeric value that must be an integer within the range of the type T, and
+// returns it as that type. Unlike Reader.Int, which converts the number from a float64, this does
+// not lose precision for large integers, and it never truncates or wraps around:
+//
+//	port := jreader.ReadInteger[uint16](r)
+//
+// A number written with a fraction or an exponent, such as 2.0 or 1e3, is accepted if its value is
+// an integer.
+//
+// If there is a parsing error, or the next value is not a number, the return value is zero and the
+// Reader enters a failed state, which you can detect with Error(). If the number is not an integer
+// or cannot be represented as a T, the return value is zero and the error is a RangeError.
+func ReadInteger[T Integer](r *Reader) T {
+	literal := r.JSONNumber()
+	if r.err != nil {
+		return 0
+	}
+	var zero T
+	if zero-1 < zero { // T is a signed type
+		if n, ok := parseInt64(string(literal)); ok && int64(T(n)) == n {
+			return T(n)
+		}
+	} else if n, ok := parseUint64(string(literal)); ok && uint64(T(n)) == n {
+		return T(n)
+	}
+	r.err = RangeError{Value: string(literal), Type: reflect.TypeOf(zero), Offset: r.tr.LastPos()}
+	return 0
+}
+
+// parseInt64 converts a JSON number literal to an int64, returning false if its value is not an
+// integer or is out of range.
+func parseInt64(literal string) (int64, bool) {
+	if n, err := strconv.ParseInt(literal, 10, 64); err == nil {
+		return n, true
+	}
+	if f, ok := parseIntegralFloat(literal); ok && f >= math.MinInt64 && f < -math.MinInt64 {
+		return int64(f), true
+	}
+	return 0, false
+}
+
+// parseUint64 converts a JSON number literal to a uint64, returning false if its value is not a
+// non-negative integer or is out of range.
+func parseUint64(literal string) (uint64, bool) {
+	if n, err := strconv.ParseUint(literal, 10, 64); err == nil {
+		return n, true
+	}
+	if f, ok := parseIntegralFloat(literal); ok && f >= 0 && f < math.MaxUint64+1.0 {
+		return uint64(f), true
+	}
+	return 0, false
+}
+
+// parseIntegralFloat handles a number literal with a fraction or an exponent, which strconv.ParseInt
+// does not accept, returning false if its value is not an integer.
+func parseIntegralFloat(literal string) (float64, bool) {
+	if !strings.ContainsAny(literal, ".eE") {
+		return 0, false // it is an integer that was out of range for ParseInt or ParseUint
+	}
+	f, err := strconv.ParseFloat(literal, 64)
+	return f, err == nil && f == math.Trunc(f)
+}
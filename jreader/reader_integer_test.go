@@ -0,0 +1,91 @@
+package jreader
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type namedInteger int16
+
+func TestReadInteger(t *testing.T) {
+	t.Run("values in range", func(t *testing.T) {
+		assertReadInteger(t, `-128`, int8(-128))
+		assertReadInteger(t, `127`, int8(127))
+		assertReadInteger(t, `255`, uint8(255))
+		assertReadInteger(t, `-32768`, namedInteger(-32768))
+		assertReadInteger(t, `4294967295`, uint32(4294967295))
+		assertReadInteger(t, `-9223372036854775808`, int64(-9223372036854775808))
+		assertReadInteger(t, `9223372036854775807`, int64(9223372036854775807))
+		assertReadInteger(t, `9007199254740993`, int64(9007199254740993))
+		assertReadInteger(t, `18446744073709551615`, uint64(18446744073709551615))
+		assertReadInteger(t, `0`, uint(0))
+	})
+
+	t.Run("integral values with fraction or exponent", func(t *testing.T) {
+		assertReadInteger(t, `2.0`, int8(2))
+		assertReadInteger(t, `-1.5e1`, int(-15))
+		assertReadInteger(t, `1E3`, uint16(1000))
+	})
+
+	t.Run("values out of range", func(t *testing.T) {
+		assertReadIntegerRangeError[int8](t, `128`)
+		assertReadIntegerRangeError[int8](t, `-129`)
+		assertReadIntegerRangeError[uint8](t, `256`)
+		assertReadIntegerRangeError[uint8](t, `-1`)
+		assertReadIntegerRangeError[namedInteger](t, `32768`)
+		assertReadIntegerRangeError[uint32](t, `4294967296`)
+		assertReadIntegerRangeError[int64](t, `9223372036854775808`)
+		assertReadIntegerRangeError[int64](t, `-9223372036854775809`)
+		assertReadIntegerRangeError[uint64](t, `18446744073709551616`)
+		assertReadIntegerRangeError[uint64](t, `-1`)
+		assertReadIntegerRangeError[int64](t, `1e19`)
+		assertReadIntegerRangeError[uint](t, `-1e0`)
+	})
+
+	t.Run("values that are not integers", func(t *testing.T) {
+		assertReadIntegerRangeError[int](t, `1.5`)
+		assertReadIntegerRangeError[uint64](t, `1e-1`)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		r := NewReader([]byte(`"1"`))
+		assert.Equal(t, 0, ReadInteger[int](&r))
+		require.IsType(t, TypeError{}, r.Error())
+		assert.Equal(t, StringValue, r.Error().(TypeError).Actual)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		r := NewReader([]byte(`[null, 3, 300]`))
+		var values []int8
+		var defined []bool
+		for arr := r.Array(); arr.Next(); {
+			value, ok := ReadOptional(&r, ReadInteger[int8])
+			values, defined = append(values, value), append(defined, ok)
+		}
+		assert.Equal(t, []int8{0, 3, 0}, values)
+		assert.Equal(t, []bool{false, true, false}, defined)
+		assert.IsType(t, RangeError{}, r.Error())
+	})
+}
+
+func assertReadInteger[T Integer](t *testing.T, input string, expected T) {
+	t.Helper()
+	r := NewReader([]byte(input))
+	value := ReadInteger[T](&r)
+	require.NoError(t, r.Error(), input)
+	assert.Equal(t, expected, value, input)
+}
+
+func assertReadIntegerRangeError[T Integer](t *testing.T, input string) {
+	t.Helper()
+	r := NewReader([]byte(input))
+	value := ReadInteger[T](&r)
+	assert.Equal(t, T(0), value, input)
+	require.IsType(t, RangeError{}, r.Error(), input)
+	e := r.Error().(RangeError)
+	assert.Equal(t, input, e.Value)
+	assert.Equal(t, reflect.TypeOf(T(0)), e.Type)
+}
@@ -0,0 +1,101 @@
+package jreader
+
+import (
+	"encoding/json"
+
+	"github.com/launchdarkly/go-jsonstream/v3/internal/ordered"
+)
+
+// InterfaceOptions specifies optional behavior for Reader.ReadInterfaceWithOptions.
+type InterfaceOptions struct {
+	// UseNumber causes numbers to be returned as json.Number values, preserving the exact text of
+	// each number, rather than as float64 values. This is equivalent to json.Decoder.UseNumber.
+	UseNumber bool
+
+	// OrderedObjects causes objects to be returned as OrderedObject values, preserving the order of
+	// their properties, rather than as map[string]interface{} values.
+	OrderedObjects bool
+}
+
+// OrderedObject is a representation of a JSON object that preserves the order of its properties.
+// It is returned by Reader.ReadInterfaceWithOptions if OrderedObjects is true, and is written as a
+// JSON object by jwriter.Writer.Value. Its Get method returns the value of the property with the
+// specified name, and true if it was found; if the name appears more than once, the last value
+// wins, as it would in a map.
+type OrderedObject = ordered.Object
+
+// ObjectProperty is a single property in an OrderedObject, with the fields Name and Value.
+type ObjectProperty = ordered.Property
+
+// ReadInterface reads a JSON value of any type, including all nested values within an array or
+// object, and returns it in the same form that encoding/json would produce when unmarshaling into
+// an empty interface:
+//
+// - A JSON null is returned as nil.
+//
+// - A JSON boolean, number, or string is returned as a bool, float64, or string.
+//
+// - A JSON array is returned as a []interface{}; an empty array is an empty non-nil slice.
+//
+// - A JSON object is returned as a map[string]interface{}. If a property name appears more than
+// once, the last value wins.
+//
+// If there is a parsing error, the return value is nil and the Reader enters a failed state, which
+// you can detect with Error().
+func (r *Reader) ReadInterface() interface{} {
+	return r.ReadInterfaceWithOptions(InterfaceOptions{})
+}
+
+// ReadInterfaceWithOptions is the same as ReadInterface, but allows numbers to be returned as
+// json.Number values and/or objects to be returned as OrderedObject values. See InterfaceOptions.
+func (r *Reader) ReadInterfaceWithOptions(options InterfaceOptions) interface{} {
+	ret := r.readInterface(options)
+	if r.err != nil {
+		return nil
+	}
+	return ret
+}
+
+func (r *Reader) readInterface(options InterfaceOptions) interface{} {
+	var value AnyValue
+	var number json.Number
+	if options.UseNumber {
+		value, number = r.AnyWithNumberLiteral()
+	} else {
+		value = r.Any()
+	}
+	switch value.Kind {
+	case BoolValue:
+		return value.Bool
+	case NumberValue:
+		if options.UseNumber {
+			return number
+		}
+		return value.Number
+	case StringValue:
+		return value.String
+	case ArrayValue:
+		ret := []interface{}{}
+		for value.Array.Next() {
+			ret = append(ret, r.readInterface(options))
+		}
+		return ret
+	case ObjectValue:
+		if options.OrderedObjects {
+			ret := OrderedObject{}
+			for value.Object.Next() {
+				name := string(value.Object.Name())
+				ret = append(ret, ObjectProperty{Name: name, Value: r.readInterface(options)})
+			}
+			return ret
+		}
+		ret := make(map[string]interface{})
+		for value.Object.Next() {
+			name := string(value.Object.Name())
+			ret[name] = r.readInterface(options)
+		}
+		return ret
+	default:
+		return nil
+	}
+}
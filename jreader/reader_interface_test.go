@@ -0,0 +1,116 @@
+package jreader
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const interfaceTestData = `{"b": true, "n": 1.5e2, "s": "x\n", "a": [1, null, []], "o": {"z": 1, "y": {}}, "nil": null}`
+
+func TestReadInterface(t *testing.T) {
+	t.Run("default options", func(t *testing.T) {
+		r := NewReader([]byte(interfaceTestData))
+		value := r.ReadInterface()
+		require.NoError(t, r.Error())
+		require.NoError(t, r.RequireEOF())
+
+		var expected interface{}
+		require.NoError(t, json.Unmarshal([]byte(interfaceTestData), &expected))
+		assert.Equal(t, expected, value)
+	})
+
+	t.Run("UseNumber", func(t *testing.T) {
+		r := NewReader([]byte(`[1.5e2, -0, 12345678901234567890]`))
+		value := r.ReadInterfaceWithOptions(InterfaceOptions{UseNumber: true})
+		require.NoError(t, r.Error())
+		assert.Equal(t, []interface{}{json.Number("1.5e2"), json.Number("-0"), json.Number("12345678901234567890")}, value)
+	})
+
+	t.Run("OrderedObjects", func(t *testing.T) {
+		r := NewReader([]byte(`{"z": 1, "y": {"b": [], "a": null}, "z": 2}`))
+		value := r.ReadInterfaceWithOptions(InterfaceOptions{OrderedObjects: true})
+		require.NoError(t, r.Error())
+		expected := OrderedObject{
+			{Name: "z", Value: float64(1)},
+			{Name: "y", Value: OrderedObject{{Name: "b", Value: []interface{}{}}, {Name: "a", Value: nil}}},
+			{Name: "z", Value: float64(2)},
+		}
+		assert.Equal(t, expected, value)
+
+		z, ok := value.(OrderedObject).Get("z")
+		assert.True(t, ok)
+		assert.Equal(t, float64(2), z)
+		_, ok = value.(OrderedObject).Get("x")
+		assert.False(t, ok)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		r := NewReader([]byte(`{"a": [1, 2}`))
+		value := r.ReadInterface()
+		assert.Error(t, r.Error())
+		assert.Nil(t, value)
+	})
+}
+
+func TestJSONNumber(t *testing.T) {
+	for _, s := range []string{"0", "-1", "1.50", "1e+100", "12345678901234567890"} {
+		t.Run(s, func(t *testing.T) {
+			r := NewReader([]byte(` ` + s + ` `))
+			assert.Equal(t, json.Number(s), r.JSONNumber())
+			assert.NoError(t, r.Error())
+			assert.NoError(t, r.RequireEOF())
+		})
+	}
+
+	t.Run("after null check", func(t *testing.T) {
+		r := NewReader([]byte(`[null, 3.25]`))
+		arr := r.Array()
+		require.True(t, arr.Next())
+		n, ok := ReadOptional(&r, (*Reader).JSONNumber)
+		assert.False(t, ok)
+		assert.Equal(t, json.Number(""), n)
+		require.True(t, arr.Next())
+		n, ok = ReadOptional(&r, (*Reader).JSONNumber)
+		assert.True(t, ok)
+		assert.Equal(t, json.Number("3.25"), n)
+		require.NoError(t, r.Error())
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		r := NewReader([]byte(`"1"`))
+		assert.Equal(t, json.Number(""), r.JSONNumber())
+		require.Error(t, r.Error())
+		assert.Equal(t, NumberValue, r.Error().(TypeError).Expected)
+		assert.Equal(t, StringValue, r.Error().(TypeError).Actual)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		r := NewReader([]byte(`]`))
+		r.JSONNumber()
+		assert.IsType(t, SyntaxError{}, r.Error())
+	})
+}
+
+func TestAnyWithNumberLiteral(t *testing.T) {
+	r := NewReader([]byte(`[1.0, "1.0", true, null, {}]`))
+	v, n := r.AnyWithNumberLiteral()
+	require.Equal(t, ArrayValue, v.Kind)
+	assert.Equal(t, json.Number(""), n)
+	expected := []struct {
+		kind   ValueKind
+		number json.Number
+	}{{NumberValue, "1.0"}, {StringValue, ""}, {BoolValue, ""}, {NullValue, ""}, {ObjectValue, ""}}
+	for _, e := range expected {
+		require.True(t, v.Array.Next())
+		element, n := r.AnyWithNumberLiteral()
+		assert.Equal(t, e.kind, element.Kind)
+		assert.Equal(t, e.number, n)
+		for element.Object.Next() {
+		}
+	}
+	assert.False(t, v.Array.Next())
+	require.NoError(t, r.Error())
+}
@@ -0,0 +1,53 @@
+//go:build go1.23
+// +build go1.23
+
+package jreader
+
+import "iter"
+
+// All returns an iterator over the array elements, for use with a range loop in Go 1.23 or later.
+// Each iteration yields the zero-based index of the element, counting from the first element that
+// this iterator visited; as with Next, you then use the Reader's methods to read the element value.
+//
+//	arr := r.Array()
+//	for i := range arr.All() {
+//	    values[i] = r.String()
+//	}
+//
+// The loop ends when the end of the array is reached or if the Reader encounters an error. If you
+// exit the loop early with break, the ArrayState is left positioned at the current element: calling
+// Next (or All) again will continue with the following element, just as it would if you had been
+// calling Next directly. This method is only available in Go 1.23 and later.
+func (arr *ArrayState) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; arr.Next(); i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the object properties, for use with a range loop in Go 1.23 or
+// later. Each iteration yields the property name, which is the same value that would be returned by
+// Name, and the Reader that you should use to read the property value.
+//
+//	obj := r.Object()
+//	for name, r := range obj.All() {
+//	    switch string(name) { ... }
+//	}
+//
+// The loop ends when the end of the object is reached or if the Reader encounters an error; any
+// required properties specified with WithRequiredProperties are checked at that point. If you exit
+// the loop early with break, the ObjectState is left positioned at the current property: calling
+// Next (or All) again will continue with the following property, just as it would if you had been
+// calling Next directly. This method is only available in Go 1.23 and later.
+func (obj *ObjectState) All() iter.Seq2[[]byte, *Reader] {
+	return func(yield func([]byte, *Reader) bool) {
+		for obj.Next() {
+			if !yield(obj.name, obj.r) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+//go:build go1.23
+// +build go1.23
+
+package jreader
+
+import "fmt"
+
+func ExampleArrayState_All() {
+	r := NewReader([]byte(`["a","b"]`))
+	arr := r.Array()
+	for i := range arr.All() {
+		fmt.Println(i, r.String())
+	}
+	// Output: 0 a
+	// 1 b
+}
+
+func ExampleObjectState_All() {
+	r := NewReader([]byte(`{"a":1,"b":2}`))
+	obj := r.Object()
+	for name, r := range obj.All() {
+		fmt.Println(string(name), r.Int())
+	}
+	// Output: a 1
+	// b 2
+}
@@ -0,0 +1,109 @@
+//go:build go1.23
+// +build go1.23
+
+package jreader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayStateAll(t *testing.T) {
+	r := NewReader([]byte(`[10, 20, 30]`))
+	var indexes, values []int
+	arr := r.Array()
+	for i := range arr.All() {
+		indexes = append(indexes, i)
+		values = append(values, r.Int())
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []int{10, 20, 30}, values)
+	assert.NoError(t, r.RequireEOF())
+}
+
+func TestArrayStateAllWithBreakCanContinue(t *testing.T) {
+	r := NewReader([]byte(`[[1, 2, 3, 4], "next"]`))
+	outer := r.Array()
+	require.True(t, outer.Next())
+
+	arr := r.Array()
+	var values []int
+	for range arr.All() {
+		values = append(values, r.Int())
+		if len(values) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2}, values)
+
+	for range arr.All() {
+		break // leaves the value 3 unread
+	}
+	for arr.Next() {
+		values = append(values, r.Int())
+	}
+	assert.Equal(t, []int{1, 2, 4}, values)
+
+	require.True(t, outer.Next())
+	assert.Equal(t, "next", r.String())
+	require.False(t, outer.Next())
+	require.NoError(t, r.Error())
+}
+
+func TestArrayStateAllStopsOnError(t *testing.T) {
+	r := NewReader([]byte(`[1, 2, 3]`))
+	err := errors.New("sorry")
+	n := 0
+	arr := r.Array()
+	for range arr.All() {
+		n++
+		r.AddError(err)
+	}
+	assert.Equal(t, 1, n)
+	assert.Equal(t, err, r.Error())
+}
+
+func TestObjectStateAll(t *testing.T) {
+	r := NewReader([]byte(`{"a": 1, "b": 2}`))
+	values := map[string]int{}
+	obj := r.Object()
+	for name, r := range obj.All() {
+		values[string(name)] = r.Int()
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, values)
+	assert.NoError(t, r.RequireEOF())
+}
+
+func TestObjectStateAllWithBreakCanContinue(t *testing.T) {
+	r := NewReader([]byte(`{"a": {"x": 1}, "b": 2, "c": 3}`))
+	var names []string
+	obj := r.Object()
+	for name := range obj.All() {
+		names = append(names, string(name))
+		break // leaves the value of "a" unread
+	}
+	for name, r := range obj.All() {
+		names = append(names, string(name))
+		assert.Equal(t, 2, r.Int())
+		break
+	}
+	for obj.Next() {
+		names = append(names, string(obj.Name()))
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestObjectStateAllChecksRequiredProperties(t *testing.T) {
+	r := NewReader([]byte(`{"a": 1}`))
+	obj := r.Object().WithRequiredProperties([]string{"a", "b"})
+	for range obj.All() {
+	}
+	require.IsType(t, RequiredPropertyError{}, r.Error())
+	assert.Equal(t, "b", r.Error().(RequiredPropertyError).Name)
+}
@@ -87,7 +87,11 @@ func (obj *ObjectState) IsDefined() bool {
 //
 // See ObjectState for example code.
 func (obj *ObjectState) Next() bool {
-	if obj.r == nil || obj.r.err != nil {
+	if obj.r == nil {
+		return false
+	}
+	if obj.r.err != nil {
+		obj.r.awaitingReadValue = false // see Reader.StringReader
 		return false
 	}
 	var isEnd bool
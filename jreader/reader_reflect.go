@@ -0,0 +1,385 @@
+package jreader
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/launchdarkly/go-jsonstream/v3/internal/structinfo"
+)
+
+//nolint:gochecknoglobals
+var (
+	readableType        = reflect.TypeOf((*Readable)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonNumberType      = reflect.TypeOf(json.Number(""))
+	orderedObjectType   = reflect.TypeOf(OrderedObject(nil))
+)
+
+// ReadInto reads a JSON value into an arbitrary Go value, using reflection to determine how the
+// JSON data should be mapped to the value's type. The target parameter must be a non-nil pointer.
+//
+// This is meant to be used for types that do not have a hand-written or generated implementation
+// of Readable, so that they can be read within the same Reader, and with the same error reporting,
+// as the rest of the data. It is considerably slower than a Readable implementation. The rules are
+// similar to those of encoding/json:
+//
+// - If the target type, or a pointer to it, implements Readable, its ReadFromJSONReader method is
+// used. Otherwise, if it implements encoding.TextUnmarshaler, the JSON value must be a string which
+// is passed to UnmarshalText.
+//
+// - Booleans, numbers, and strings are read into Go values of the corresponding kind. Numbers are
+// converted to Go integer types in the same way as ReadInteger: if the number is not an integer, or
+// is out of range for the type, it causes a RangeError.
+//
+// - A JSON array is read into a slice or array. A JSON object is read into a map whose keys are
+// strings or integers, or into a struct. A []byte is read from a base64-encoded string.
+//
+// - Struct fields are matched to JSON property names using the same "json" struct tags as
+// encoding/json, including the "string" option, and fields of embedded structs are promoted.
+// Unrecognized properties are ignored. A field can be tagged with `jsonstream:"required"` to cause
+// a RequiredPropertyError if the property is missing.
+//
+// - A JSON null is read into a pointer, slice, map, or interface as nil. For any other type, a null
+// causes a TypeError, as it would with the Reader method for that type.
+//
+// - A value that is read into an empty interface is stored as described for Reader.ReadInterface.
+// A json.Number is read from a JSON number, preserving its exact text. An OrderedObject is read
+// from a JSON object, or a null.
+//
+// If there is a parsing error, or the JSON value does not match the target type, or the target type
+// contains a type that cannot be represented in JSON (such as a channel or a function), the Reader
+// enters a failed state, which you can detect with Error(). In the last case, the error is an
+// UnsupportedTypeError. The target may have been partly modified.
+func ReadInto(r *Reader, target interface{}) {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		r.awaitingReadValue = false
+		r.AddError(UnsupportedTypeError{Type: reflect.TypeOf(target)})
+		return
+	}
+	r.readReflectValue(v.Elem())
+}
+
+func (r *Reader) readReflectValue(v reflect.Value) {
+	if r.err != nil {
+		r.awaitingReadValue = false
+		return
+	}
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		r.readReflectPointer(v)
+		return
+	}
+	if reflect.PointerTo(t).Implements(readableType) {
+		v.Addr().Interface().(Readable).ReadFromJSONReader(r)
+		return
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		s := r.String()
+		if r.err == nil {
+			r.AddError(v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)))
+		}
+		return
+	}
+	switch t {
+	case jsonNumberType:
+		v.SetString(string(r.JSONNumber()))
+		return
+	case orderedObjectType:
+		r.readReflectOrderedObject(v)
+		return
+	}
+	switch t.Kind() { //nolint:exhaustive // all other kinds are unsupported
+	case reflect.Bool:
+		v.SetBool(r.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.readReflectInteger(v)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.Float64())
+	case reflect.String:
+		v.SetString(r.String())
+	case reflect.Interface:
+		r.readReflectInterface(v)
+	case reflect.Slice:
+		r.readReflectSlice(v)
+	case reflect.Array:
+		r.readReflectArray(v)
+	case reflect.Map:
+		r.readReflectMap(v)
+	case reflect.Struct:
+		r.readReflectStruct(v)
+	default:
+		r.awaitingReadValue = false
+		r.AddError(UnsupportedTypeError{Type: t, Offset: r.tr.LastPos()})
+	}
+}
+
+// readReflectInteger reads a number into a value of any integer kind, in the same way as ReadInteger.
+func (r *Reader) readReflectInteger(v reflect.Value) {
+	literal := r.JSONNumber()
+	if r.err != nil {
+		return
+	}
+	switch v.Kind() { //nolint:exhaustive // readReflectValue only calls this for integer kinds
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := parseInt64(string(literal)); ok && !v.OverflowInt(n) {
+			v.SetInt(n)
+			return
+		}
+	default:
+		if n, ok := parseUint64(string(literal)); ok && !v.OverflowUint(n) {
+			v.SetUint(n)
+			return
+		}
+	}
+	r.err = RangeError{Value: string(literal), Type: v.Type(), Offset: r.tr.LastPos()}
+}
+
+func (r *Reader) readReflectPointer(v reflect.Value) {
+	r.awaitingReadValue = false
+	isNull, err := r.tr.Null()
+	if err != nil {
+		r.err = err
+		return
+	}
+	if isNull {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	r.readReflectValue(v.Elem())
+}
+
+func (r *Reader) readReflectInterface(v reflect.Value) {
+	if v.NumMethod() != 0 {
+		// We can't know what concrete type to use for a non-empty interface, so only null is allowed.
+		r.awaitingReadValue = false
+		isNull, err := r.tr.Null()
+		if err != nil {
+			r.err = err
+		} else if isNull {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			r.err = UnsupportedTypeError{Type: v.Type(), Offset: r.tr.LastPos()}
+		}
+		return
+	}
+	value := r.ReadInterface()
+	if r.err != nil {
+		return
+	}
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+	} else {
+		v.Set(reflect.ValueOf(value))
+	}
+}
+
+func (r *Reader) readReflectOrderedObject(v reflect.Value) {
+	obj := r.ObjectOrNull()
+	if r.err != nil {
+		return
+	}
+	if !obj.IsDefined() {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+	ret := OrderedObject{}
+	for obj.Next() {
+		name := string(obj.Name())
+		ret = append(ret, ObjectProperty{Name: name, Value: r.readInterface(InterfaceOptions{OrderedObjects: true})})
+	}
+	if r.err == nil {
+		v.Set(reflect.ValueOf(ret))
+	}
+}
+
+func (r *Reader) readReflectSlice(v reflect.Value) {
+	t := v.Type()
+	if t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(readableType) {
+		s, nonNull := r.StringOrNull()
+		if r.err != nil {
+			return
+		}
+		if !nonNull {
+			v.Set(reflect.Zero(t))
+			return
+		}
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			r.err = err
+			return
+		}
+		v.SetBytes(data)
+		return
+	}
+	arr := r.ArrayOrNull()
+	if r.err != nil {
+		return
+	}
+	if !arr.IsDefined() {
+		v.Set(reflect.Zero(t))
+		return
+	}
+	slice := reflect.MakeSlice(t, 0, 0)
+	for i := 0; arr.Next(); i++ {
+		slice = reflect.Append(slice, reflect.Zero(t.Elem()))
+		r.readReflectValue(slice.Index(i))
+	}
+	v.Set(slice)
+}
+
+func (r *Reader) readReflectArray(v reflect.Value) {
+	arr := r.Array()
+	i := 0
+	for ; arr.Next(); i++ {
+		if i < v.Len() {
+			r.readReflectValue(v.Index(i))
+		} // else the value will be skipped by arr.Next()
+	}
+	if r.err == nil {
+		for ; i < v.Len(); i++ {
+			v.Index(i).Set(reflect.Zero(v.Type().Elem()))
+		}
+	}
+}
+
+func (r *Reader) readReflectMap(v reflect.Value) {
+	t := v.Type()
+	keyType := t.Key()
+	switch keyType.Kind() { //nolint:exhaustive // all other kinds are unsupported
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		if !reflect.PointerTo(keyType).Implements(textUnmarshalerType) {
+			r.awaitingReadValue = false
+			r.AddError(UnsupportedTypeError{Type: t, Offset: r.tr.LastPos()})
+			return
+		}
+	}
+	obj := r.ObjectOrNull()
+	if r.err != nil {
+		return
+	}
+	if !obj.IsDefined() {
+		v.Set(reflect.Zero(t))
+		return
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	for obj.Next() {
+		key := reflect.New(keyType).Elem()
+		if err := setMapKey(key, obj.Name()); err != nil {
+			r.AddError(err)
+			return
+		}
+		value := reflect.New(t.Elem()).Elem()
+		r.readReflectValue(value)
+		if r.err != nil {
+			return
+		}
+		v.SetMapIndex(key, value)
+	}
+}
+
+func setMapKey(key reflect.Value, name []byte) error {
+	if reflect.PointerTo(key.Type()).Implements(textUnmarshalerType) {
+		return key.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(name)
+	}
+	switch key.Kind() { //nolint:exhaustive // other kinds were already excluded by readReflectMap
+	case reflect.String:
+		key.SetString(string(name))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(string(name), 10, key.Type().Bits())
+		if err != nil {
+			return err
+		}
+		key.SetInt(n)
+	default:
+		n, err := strconv.ParseUint(string(name), 10, key.Type().Bits())
+		if err != nil {
+			return err
+		}
+		key.SetUint(n)
+	}
+	return nil
+}
+
+func (r *Reader) readReflectStruct(v reflect.Value) {
+	info := structinfo.ForType(v.Type())
+	obj := r.Object()
+	if len(info.RequiredNames) != 0 {
+		obj = obj.WithRequiredProperties(info.RequiredNames)
+	}
+	for obj.Next() {
+		field := info.Lookup(obj.Name())
+		if field == nil {
+			continue // Next will skip the value
+		}
+		fv := fieldForWriting(v, field.Index)
+		if !fv.IsValid() {
+			continue
+		}
+		if field.AsString {
+			r.readReflectQuotedValue(fv)
+		} else {
+			r.readReflectValue(fv)
+		}
+	}
+}
+
+// fieldForWriting returns the struct field with the specified index sequence, allocating any
+// embedded struct pointers along the way. It returns an invalid Value if an embedded struct pointer
+// is nil and cannot be set because it is unexported.
+func fieldForWriting(v reflect.Value, index []int) reflect.Value {
+	for i, fieldIndex := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIndex)
+	}
+	return v
+}
+
+// readReflectQuotedValue reads a field that has the "string" option, meaning that a boolean or
+// numeric value is encoded as a JSON string.
+func (r *Reader) readReflectQuotedValue(v reflect.Value) {
+	s := r.String()
+	if r.err != nil {
+		return
+	}
+	var err error
+	switch v.Kind() { //nolint:exhaustive // structinfo only sets AsString for these kinds
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, v.Type().Bits())
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, v.Type().Bits())
+		v.SetUint(n)
+	default:
+		var f float64
+		f, err = strconv.ParseFloat(s, v.Type().Bits())
+		v.SetFloat(f)
+	}
+	r.AddError(err)
+}
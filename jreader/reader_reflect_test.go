@@ -0,0 +1,235 @@
+package jreader
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reflectEmbedded struct {
+	Inner  string `json:"inner"`
+	Hidden string `json:"outer"` // hidden by the field of the same name in the outer struct
+}
+
+type reflectStruct struct {
+	reflectEmbedded
+	Outer    string            `json:"outer"`
+	Bool     bool              `json:"bool"`
+	Int      int               `json:"int"`
+	Uint8    uint8             `json:"uint8"`
+	Float32  float32           `json:"float32"`
+	Quoted   int64             `json:"quoted,string"`
+	Ptr      *string           `json:"ptr"`
+	Slice    []int             `json:"slice"`
+	Array    [2]int            `json:"array"`
+	Bytes    []byte            `json:"bytes"`
+	Map      map[string]bool   `json:"map"`
+	IntMap   map[int]string    `json:"intMap"`
+	Any      interface{}       `json:"any"`
+	Item     readableItem      `json:"item"`
+	Time     time.Time         `json:"time"`
+	Untagged string            //nolint:tagliatelle
+	Ignored  string            `json:"-"`
+	Items    map[string]*int64 `json:"items"`
+	private  string
+}
+
+func TestReadInto(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		data := `{
+			"inner": "a", "outer": "b", "bool": true, "int": 3e0, "uint8": 200, "float32": 1.5,
+			"quoted": "123", "ptr": "c", "slice": [1, 2], "array": [3], "bytes": "aGk=",
+			"map": {"x": true}, "intMap": {"1": "one"}, "any": [1, "two", {"three": null}],
+			"item": {"key": "k", "count": 4}, "time": "2020-01-02T03:04:05Z",
+			"UNTAGGED": "d", "Ignored": "e", "items": {"i": 5, "j": null}, "private": "f",
+			"unknown": [1, 2, 3]
+		}`
+		r := NewReader([]byte(data))
+		var value reflectStruct
+		value.Array[1] = 99
+		ReadInto(&r, &value)
+		require.NoError(t, r.Error())
+		require.NoError(t, r.RequireEOF())
+
+		c, five := "c", int64(5)
+		expected := reflectStruct{
+			reflectEmbedded: reflectEmbedded{Inner: "a"},
+			Outer:           "b",
+			Bool:            true,
+			Int:             3,
+			Uint8:           200,
+			Float32:         1.5,
+			Quoted:          123,
+			Ptr:             &c,
+			Slice:           []int{1, 2},
+			Array:           [2]int{3, 0},
+			Bytes:           []byte("hi"),
+			Map:             map[string]bool{"x": true},
+			IntMap:          map[int]string{1: "one"},
+			Any:             []interface{}{float64(1), "two", map[string]interface{}{"three": nil}},
+			Item:            readableItem{key: "k", count: 4},
+			Time:            time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Untagged:        "d",
+			Items:           map[string]*int64{"i": &five, "j": nil},
+		}
+		assert.Equal(t, expected, value)
+	})
+
+	t.Run("nulls", func(t *testing.T) {
+		s := "x"
+		value := struct {
+			Ptr   *string
+			Slice []int
+			Map   map[string]int
+			Any   interface{}
+		}{&s, []int{1}, map[string]int{"a": 1}, "y"}
+		r := NewReader([]byte(`{"Ptr": null, "Slice": null, "Map": null, "Any": null}`))
+		ReadInto(&r, &value)
+		require.NoError(t, r.Error())
+		assert.Nil(t, value.Ptr)
+		assert.Nil(t, value.Slice)
+		assert.Nil(t, value.Map)
+		assert.Nil(t, value.Any)
+	})
+
+	t.Run("json.Number and OrderedObject", func(t *testing.T) {
+		var value struct {
+			N json.Number
+			O OrderedObject
+		}
+		r := NewReader([]byte(`{"N": 12345678901234567890, "O": {"b": 1, "a": 2}}`))
+		ReadInto(&r, &value)
+		require.NoError(t, r.Error())
+		assert.Equal(t, json.Number("12345678901234567890"), value.N)
+		assert.Equal(t, OrderedObject{{Name: "b", Value: float64(1)}, {Name: "a", Value: float64(2)}}, value.O)
+	})
+
+	t.Run("embedded pointer is allocated if exported", func(t *testing.T) {
+		type Embedded struct {
+			Field string `json:"field"`
+		}
+		var value struct {
+			*Embedded
+			*reflectEmbedded
+			Other int
+		}
+		r := NewReader([]byte(`{"field": "a", "inner": "b", "Other": 1}`))
+		ReadInto(&r, &value)
+		require.NoError(t, r.Error())
+		require.NotNil(t, value.Embedded)
+		assert.Equal(t, "a", value.Field)
+		assert.Nil(t, value.reflectEmbedded)
+		assert.Equal(t, 1, value.Other)
+	})
+
+	t.Run("required property", func(t *testing.T) {
+		var value struct {
+			Key  string `json:"key" jsonstream:"required"`
+			Name string `json:"name"`
+		}
+		r := NewReader([]byte(`{"name": "x"}`))
+		ReadInto(&r, &value)
+		require.Error(t, r.Error())
+		assert.Equal(t, "key", r.Error().(RequiredPropertyError).Name)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		var value struct {
+			Name string `json:"name"`
+		}
+		r := NewReader([]byte(`{"name": 3}`))
+		ReadInto(&r, &value)
+		require.Error(t, r.Error())
+		assert.Equal(t, StringValue, r.Error().(TypeError).Expected)
+	})
+
+	t.Run("null for non-nullable type", func(t *testing.T) {
+		var value int
+		r := NewReader([]byte(`null`))
+		ReadInto(&r, &value)
+		require.Error(t, r.Error())
+		assert.Equal(t, NumberValue, r.Error().(TypeError).Expected)
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		var value struct {
+			I8  int8
+			I64 int64
+			U16 uint16
+			U64 uint64
+			P   uintptr
+		}
+		r := NewReader([]byte(`{"I8": -128, "I64": 9223372036854775807, "U16": 65535,
+			"U64": 18446744073709551615, "P": 1}`))
+		ReadInto(&r, &value)
+		require.NoError(t, r.Error())
+		assert.Equal(t, int8(-128), value.I8)
+		assert.Equal(t, int64(9223372036854775807), value.I64)
+		assert.Equal(t, uint16(65535), value.U16)
+		assert.Equal(t, uint64(18446744073709551615), value.U64)
+		assert.Equal(t, uintptr(1), value.P)
+	})
+
+	t.Run("integer out of range", func(t *testing.T) {
+		for _, p := range []struct {
+			input  string
+			target interface{}
+		}{
+			{`128`, new(int8)},
+			{`-129`, new(int8)},
+			{`9223372036854775808`, new(int64)},
+			{`1.5`, new(int)},
+			{`65536`, new(uint16)},
+			{`-1`, new(uint)},
+			{`18446744073709551616`, new(uint64)},
+			{`[1, 128]`, new([]int8)},
+			{`{"a": -1}`, new(map[string]uint32)},
+		} {
+			r := NewReader([]byte(p.input))
+			ReadInto(&r, p.target)
+			require.IsType(t, RangeError{}, r.Error(), p.input)
+		}
+	})
+
+	t.Run("bad string option value", func(t *testing.T) {
+		var value struct {
+			N int `json:"n,string"`
+		}
+		r := NewReader([]byte(`{"n": "x"}`))
+		ReadInto(&r, &value)
+		assert.Error(t, r.Error())
+	})
+
+	t.Run("bad map key", func(t *testing.T) {
+		var value map[int]bool
+		r := NewReader([]byte(`{"x": true}`))
+		ReadInto(&r, &value)
+		assert.Error(t, r.Error())
+	})
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, target := range []interface{}{
+			new(chan int),
+			new(map[float64]int),
+			new(error),
+			"not a pointer",
+			(*int)(nil),
+		} {
+			r := NewReader([]byte(`{"a": 1}`))
+			ReadInto(&r, target)
+			require.Error(t, r.Error())
+			assert.IsType(t, UnsupportedTypeError{}, r.Error())
+		}
+	})
+
+	t.Run("does nothing if reader has already failed", func(t *testing.T) {
+		value := 1
+		r := NewReader([]byte(`2`))
+		r.AddError(SyntaxError{})
+		ReadInto(&r, &value)
+		assert.Equal(t, 1, value)
+	})
+}
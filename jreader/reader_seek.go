@@ -0,0 +1,75 @@
+package jreader
+
+import "github.com/launchdarkly/go-jsonstream/v3/jpointer"
+
+// Seek advances the Reader to the value that a JSON Pointer refers to, within the JSON value that
+// the Reader is about to read. It descends into arrays and objects along the path that the pointer
+// describes, skipping over all other values without parsing them into Go values.
+//
+// If the target value exists, Seek returns true, and the Reader is positioned so that the next
+// read operation will read the target value. The arrays and objects that contain the target are
+// left unfinished, so after reading the target value you should not use the Reader for anything
+// else (for instance, RequireEOF will fail).
+//
+// If the target value does not exist, Seek returns false; the Reader has consumed input at least
+// up to the point where it was determined that the target does not exist, and it should not be
+// used further. A missing target is not an error, so Error() will still return nil.
+//
+// If there is a parsing error before the target is reached, Seek returns false and the Reader
+// enters a failed state, which you can detect with Error().
+//
+//	r := jreader.NewReader(data)
+//	if r.Seek(jpointer.MustParse("/items/3/key")) {
+//	    key := r.String()
+//	}
+func (r *Reader) Seek(pointer jpointer.Pointer) bool {
+	for i := 0; i < pointer.Len(); i++ {
+		if !r.seekToken(pointer.Token(i)) {
+			return false
+		}
+	}
+	return r.err == nil
+}
+
+func (r *Reader) seekToken(token string) bool {
+	value := r.any(true)
+	switch value.Kind {
+	case ArrayValue:
+		index, ok := jpointer.ArrayIndex(token)
+		for i := 0; value.Array.Next(); i++ {
+			if ok && i == index {
+				return true
+			}
+		}
+	case ObjectValue:
+		for value.Object.Next() {
+			if string(value.Object.Name()) == token {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Find creates a Reader for the specified JSON data, and then uses Reader.Seek to advance it to the
+// value that a JSON Pointer refers to, if that value exists. The pointer is given in string form;
+// if it is not a valid JSON Pointer, the returned Reader is in a failed state with a
+// jpointer.SyntaxError.
+//
+//	r, found := jreader.Find(data, "/items/3/key")
+//	if found {
+//	    key := r.String()
+//	}
+//	if err := r.Error(); err != nil {
+//	    // the pointer was invalid, or the data was malformed
+//	}
+func Find(data []byte, pointer string) (Reader, bool) {
+	r := NewReader(data)
+	p, err := jpointer.Parse(pointer)
+	if err != nil {
+		r.AddError(err)
+		return r, false
+	}
+	found := r.Seek(p)
+	return r, found
+}
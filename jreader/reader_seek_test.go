@@ -0,0 +1,89 @@
+package jreader
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jpointer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const seekTestData = `{
+	"a": {"b": [10, {"c": "found"}, [true]]},
+	"skipped": {"x": [1, 2, {"y": null}]},
+	"a/b": 1,
+	"m~n": 2,
+	"": 3
+}`
+
+func TestSeek(t *testing.T) {
+	for _, p := range []struct {
+		pointer  string
+		expected interface{}
+	}{
+		{``, nil},
+		{`/a/b/0`, float64(10)},
+		{`/a/b/1`, map[string]interface{}{"c": "found"}},
+		{`/a/b/1/c`, "found"},
+		{`/a/b/2/0`, true},
+		{`/skipped/x/2/y`, nil},
+		{`/a~1b`, float64(1)},
+		{`/m~0n`, float64(2)},
+		{`/`, float64(3)},
+	} {
+		t.Run(p.pointer, func(t *testing.T) {
+			r := NewReader([]byte(seekTestData))
+			require.True(t, r.Seek(jpointer.MustParse(p.pointer)))
+			value := r.ReadInterface()
+			require.NoError(t, r.Error())
+			if p.pointer != `` {
+				assert.Equal(t, p.expected, value)
+			}
+		})
+	}
+
+	for _, pointer := range []string{
+		`/x`,
+		`/a/c`,
+		`/a/b/3`,
+		`/a/b/-`,
+		`/a/b/01`,
+		`/a/b/0/0`,
+		`/a/b/1/c/d`,
+		`/skipped/y`,
+	} {
+		t.Run(pointer, func(t *testing.T) {
+			r := NewReader([]byte(seekTestData))
+			assert.False(t, r.Seek(jpointer.MustParse(pointer)))
+			assert.NoError(t, r.Error())
+		})
+	}
+
+	t.Run("syntax error before target", func(t *testing.T) {
+		r := NewReader([]byte(`{"a": [1, 2, {"x": y}], "b": 2}`))
+		assert.False(t, r.Seek(jpointer.MustParse("/b")))
+		assert.Error(t, r.Error())
+	})
+
+	t.Run("reader already failed", func(t *testing.T) {
+		r := NewReader([]byte(`{"a": 1}`))
+		r.AddError(SyntaxError{})
+		assert.False(t, r.Seek(jpointer.MustParse("/a")))
+	})
+}
+
+func TestFind(t *testing.T) {
+	r, found := Find([]byte(seekTestData), "/a/b/1/c")
+	assert.True(t, found)
+	assert.Equal(t, "found", r.String())
+	assert.NoError(t, r.Error())
+
+	r, found = Find([]byte(seekTestData), "/a/b/1/d")
+	assert.False(t, found)
+	assert.NoError(t, r.Error())
+
+	r, found = Find([]byte(seekTestData), "a")
+	assert.False(t, found)
+	assert.IsType(t, jpointer.SyntaxError{}, r.Error())
+}
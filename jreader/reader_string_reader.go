@@ -0,0 +1,203 @@
+package jreader
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+var errStringReaderInProgress = errors.New( //nolint:gochecknoglobals
+	"cannot read another value until the string from StringReader has been read to the end")
+
+// stringReader is the io.Reader returned by Reader.StringReader.
+type stringReader struct {
+	r *Reader
+
+	// content is the text between the quote marks. If escaped is true, it still contains escape
+	// sequences, which are decoded as we go; offset is the position of the string in the input.
+	// sawEscape is set once we have decoded an escape sequence, since readString replaces invalid
+	// UTF-8 after that point but not before it; validTo is the end of the text after pos that is
+	// known to be valid, which may end in the middle of a character if the caller's buffer was full.
+	content   []byte
+	escaped   bool
+	sawEscape bool
+	validTo   int
+	offset    int
+	pos       int
+
+	// If a decoded character did not fit in the caller's buffer, pending holds its remaining bytes.
+	pending    [utf8.UTFMax]byte
+	pendingLen int
+	pendingPos int
+
+	err  error
+	done bool
+}
+
+// StringReader attempts to read a string value, and returns an io.Reader that provides its
+// content in any number of pieces, unescaping it as it goes. This can be used instead of String to
+// avoid allocating memory for the whole value at once, for instance to decode a large base64 value
+// with base64.NewDecoder. The content is exactly the same as what String would return.
+//
+// If there is a parsing error, or the next value is not a string, the Reader enters a failed state,
+// and the returned io.Reader returns the same error.
+//
+// No other Reader, ArrayState, or ObjectState methods should be called until the io.Reader has
+// returned io.EOF. Until then, the Reader is in a failed state: Error returns an error saying that
+// a string is still being read, and if another method is called before the content has been read
+// to the end, the Reader remains in that state permanently. Use io.Copy(io.Discard, reader) if you
+// want to skip the rest of the content.
+//
+// With the easyjson implementation (see package documentation), a string that contains escape
+// sequences is unescaped all at once.
+func (r *Reader) StringReader() io.Reader {
+	r.awaitingReadValue = false
+	if r.err != nil {
+		return &stringReader{err: r.err}
+	}
+	content, escaped, err := r.tr.StringContent()
+	if err != nil {
+		r.err = err
+		return &stringReader{err: err}
+	}
+	// Until the string has been read to the end, any other operation on the Reader fails. We set
+	// awaitingReadValue so we can tell whether another Reader method has been called, since they
+	// all clear it.
+	r.err = errStringReaderInProgress
+	r.awaitingReadValue = true
+	return &stringReader{r: r, content: content, escaped: escaped, offset: r.tr.LastPos()}
+}
+
+// Read provides the next part of the string content.
+func (s *stringReader) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.r.err != errStringReaderInProgress || !s.r.awaitingReadValue { //nolint:errorlint
+		s.err = s.r.err
+		return 0, s.err
+	}
+	n := 0
+	for n < len(p) {
+		if s.pendingPos < s.pendingLen {
+			copied := copy(p[n:], s.pending[s.pendingPos:s.pendingLen])
+			s.pendingPos += copied
+			n += copied
+			continue
+		}
+		if s.pos >= len(s.content) {
+			break
+		}
+		if !s.escaped || s.content[s.pos] != '\\' {
+			end := len(s.content)
+			if s.escaped {
+				if i := bytes.IndexByte(s.content[s.pos:], '\\'); i >= 0 {
+					end = s.pos + i
+				}
+				if s.sawEscape {
+					if s.pos >= s.validTo {
+						valid := validUTF8Prefix(s.content[s.pos:end])
+						if valid == 0 {
+							s.pos++
+							s.pendingLen, s.pendingPos = utf8.EncodeRune(s.pending[:], utf8.RuneError), 0
+							continue
+						}
+						s.validTo = s.pos + valid
+					}
+					end = s.validTo
+				}
+			}
+			copied := copy(p[n:], s.content[s.pos:end])
+			s.pos += copied
+			n += copied
+			continue
+		}
+		ch, size := decodeEscape(s.content[s.pos:])
+		if size == 0 {
+			s.err = SyntaxError{Message: errMsgInvalidString, Offset: s.offset}
+			s.r.err = s.err
+			return n, s.err
+		}
+		s.pos += size
+		s.sawEscape = true
+		s.pendingLen, s.pendingPos = utf8.EncodeRune(s.pending[:], ch), 0 // a surrogate becomes U+FFFD
+	}
+	if s.pos >= len(s.content) && s.pendingPos >= s.pendingLen {
+		s.err = io.EOF
+		s.r.err = nil
+		s.r.awaitingReadValue = false
+	}
+	if n == 0 {
+		return 0, s.err
+	}
+	return n, nil
+}
+
+// decodeEscape decodes the escape sequence at the start of data, returning the character and the
+// length of the sequence, or a length of zero if it is not valid. As in readString, each \u escape
+// is decoded separately, so a UTF-16 surrogate is returned as is; utf8.EncodeRune turns it into
+// U+FFFD.
+func decodeEscape(data []byte) (rune, int) {
+	if len(data) < 2 {
+		return 0, 0 // COVERAGE: StringContent does not allow a string to end with a backslash
+	}
+	switch data[1] {
+	case '"', '\\', '/':
+		return rune(data[1]), 2
+	case 'b':
+		return '\b', 2
+	case 'f':
+		return '\f', 2
+	case 'n':
+		return '\n', 2
+	case 'r':
+		return '\r', 2
+	case 't':
+		return '\t', 2
+	case 'u':
+		ch, ok := decodeHex4(data[2:])
+		if !ok {
+			return 0, 0
+		}
+		return ch, 6
+	default:
+		return 0, 0
+	}
+}
+
+// validUTF8Prefix returns the length of the longest prefix of the data that is valid UTF-8.
+func validUTF8Prefix(data []byte) int {
+	for i := 0; i < len(data); {
+		if data[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		ch, size := utf8.DecodeRune(data[i:])
+		if ch == utf8.RuneError && size == 1 {
+			return i
+		}
+		i += size
+	}
+	return len(data)
+}
+
+func decodeHex4(data []byte) (rune, bool) {
+	if len(data) < 4 {
+		return 0, false
+	}
+	var value rune
+	for _, ch := range data[:4] {
+		switch {
+		case ch >= '0' && ch <= '9':
+			value = value<<4 | rune(ch-'0')
+		case ch >= 'a' && ch <= 'f':
+			value = value<<4 | rune(ch-'a'+10)
+		case ch >= 'A' && ch <= 'F':
+			value = value<<4 | rune(ch-'A'+10)
+		default:
+			return 0, false
+		}
+	}
+	return value, true
+}
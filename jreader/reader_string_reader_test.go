@@ -0,0 +1,166 @@
+package jreader
+
+import (
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringReader(t *testing.T) {
+	for _, p := range []struct {
+		name, json, expected string
+	}{
+		{"empty", `""`, ""},
+		{"no escapes", `"abc é"`, "abc é"},
+		{"simple escapes", `"a\"\\\/\b\f\n\r\tz"`, "a" + `"` + "\\/\b\f\n\r\tz"},
+		{"unicode escapes", `"\u00e9\u20AC"`, "é€"},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			r := NewReader([]byte(p.json))
+			data, err := io.ReadAll(r.StringReader())
+			require.NoError(t, err)
+			assert.Equal(t, p.expected, string(data))
+			require.NoError(t, r.Error())
+			require.NoError(t, r.RequireEOF())
+
+			// iotest.TestReader reads with buffers of various sizes, including one byte at a time
+			r = NewReader([]byte(p.json))
+			assert.NoError(t, iotest.TestReader(r.StringReader(), []byte(p.expected)))
+		})
+	}
+}
+
+func TestStringReaderMatchesString(t *testing.T) {
+	for _, input := range []string{
+		`"\ud83d\ude00"`,
+		`"a\ud83db\ude00"`,
+		"\"a\xffb\"",
+		"\"a\xffb\\n\xfec\xe2\x82\"",
+		"\"\\u00e9\xe2\x82\xac\xe2\x82\"",
+		"\"\\n\xc3\"",
+		"\"a\x01b\\t\x1f\"",
+	} {
+		t.Run(input, func(t *testing.T) {
+			r := NewReader([]byte(input))
+			expected := r.String()
+			require.NoError(t, r.Error())
+
+			r = NewReader([]byte(input))
+			data, err := io.ReadAll(r.StringReader())
+			require.NoError(t, err)
+			assert.Equal(t, expected, string(data))
+
+			r = NewReader([]byte(input))
+			assert.NoError(t, iotest.TestReader(r.StringReader(), []byte(expected)))
+		})
+	}
+}
+
+func TestStringReaderInArrayAndObject(t *testing.T) {
+	r := NewReader([]byte(`{"a": ["\u0041bc", "de"], "b": "f", "c": 1}`))
+	var values []string
+	for obj := r.Object(); obj.Next(); {
+		switch string(obj.Name()) {
+		case "a":
+			for arr := r.Array(); arr.Next(); {
+				data, err := io.ReadAll(r.StringReader())
+				require.NoError(t, err)
+				values = append(values, string(data))
+			}
+		case "b":
+			data, err := io.ReadAll(r.StringReader())
+			require.NoError(t, err)
+			values = append(values, string(data))
+		}
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, []string{"Abc", "de", "f"}, values)
+}
+
+func TestStringReaderWithBase64Decoder(t *testing.T) {
+	content := strings.Repeat("0123456789", 100)
+	r := NewReader([]byte(`{"data":"` + base64.StdEncoding.EncodeToString([]byte(content)) + `"}`))
+	obj := r.Object()
+	require.True(t, obj.Next())
+	data, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, r.StringReader()))
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	require.False(t, obj.Next())
+	require.NoError(t, r.Error())
+}
+
+func TestStringReaderWrongType(t *testing.T) {
+	r := NewReader([]byte(`[1]`))
+	sr := r.StringReader()
+	require.Error(t, r.Error())
+	assert.IsType(t, TypeError{}, r.Error())
+	_, err := sr.Read(make([]byte, 10))
+	assert.Equal(t, r.Error(), err)
+}
+
+func TestStringReaderAfterError(t *testing.T) {
+	r := NewReader([]byte(`"abc"`))
+	r.AddError(io.ErrUnexpectedEOF)
+	_, err := r.StringReader().Read(make([]byte, 10))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestStringReaderInvalidString(t *testing.T) {
+	for _, input := range []string{`"abc`, `"a\xb"`, `"a\u12"`, `"a\u12g4"`} {
+		t.Run(input, func(t *testing.T) {
+			r := NewReader([]byte(input))
+			_, err := io.ReadAll(r.StringReader())
+			require.Error(t, err)
+			assert.Equal(t, err, r.Error())
+		})
+	}
+}
+
+func TestReadingOtherValueBeforeStringReaderIsExhaustedIsAnError(t *testing.T) {
+	t.Run("Reader method", func(t *testing.T) {
+		r := NewReader([]byte(`["abcdef", 1]`))
+		arr := r.Array()
+		require.True(t, arr.Next())
+		sr := r.StringReader()
+		buf := make([]byte, 3)
+		_, err := sr.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, errStringReaderInProgress, r.Error(), "Error reports the string that is in progress")
+
+		assert.Equal(t, 0, r.Int())
+		assert.Equal(t, errStringReaderInProgress, r.Error())
+
+		_, err = io.ReadAll(sr)
+		assert.Equal(t, errStringReaderInProgress, err)
+		assert.Equal(t, errStringReaderInProgress, r.Error())
+	})
+
+	t.Run("ArrayState.Next", func(t *testing.T) {
+		r := NewReader([]byte(`["abcdef", 1]`))
+		arr := r.Array()
+		require.True(t, arr.Next())
+		sr := r.StringReader()
+		require.False(t, arr.Next())
+
+		_, err := io.ReadAll(sr)
+		assert.Equal(t, errStringReaderInProgress, err)
+		assert.Equal(t, errStringReaderInProgress, r.Error())
+	})
+
+	t.Run("ObjectState.Next", func(t *testing.T) {
+		r := NewReader([]byte(`{"a": "abcdef", "b": 1}`))
+		obj := r.Object()
+		require.True(t, obj.Next())
+		sr := r.StringReader()
+		require.False(t, obj.Next())
+
+		_, err := io.ReadAll(sr)
+		assert.Equal(t, errStringReaderInProgress, err)
+		assert.Equal(t, errStringReaderInProgress, r.Error())
+	})
+}
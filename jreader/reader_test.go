@@ -350,6 +350,50 @@ func (f readerErrorTestFactory) ExpectSyntaxError(err error) error {
 	return tokenReaderErrorTestFactory{}.ExpectSyntaxError(err)
 }
 
+func TestReaderFloat32(t *testing.T) {
+	r := NewReader([]byte(`[0.1, 16777217, 3.4e38, null, 1]`))
+	arr := r.Array()
+	require.True(t, arr.Next())
+	require.Equal(t, float32(0.1), r.Float32())
+	require.True(t, arr.Next())
+	require.Equal(t, float32(16777216), r.Float32())
+	require.True(t, arr.Next())
+	require.Equal(t, float32(3.4e38), r.Float32())
+	require.True(t, arr.Next())
+	val, nonNull := r.Float32OrNull()
+	require.False(t, nonNull)
+	require.Equal(t, float32(0), val)
+	require.True(t, arr.Next())
+	val, nonNull = r.Float32OrNull()
+	require.True(t, nonNull)
+	require.Equal(t, float32(1), val)
+	require.False(t, arr.Next())
+	require.NoError(t, r.Error())
+}
+
+func TestReaderFloat32Errors(t *testing.T) {
+	r := NewReader([]byte(`1e39`))
+	require.Equal(t, float32(0), r.Float32())
+	require.IsType(t, RangeError{}, r.Error())
+	require.Equal(t, "1e39", r.Error().(RangeError).Value)
+
+	r = NewReader([]byte(`-1e39`))
+	_, nonNull := r.Float32OrNull()
+	require.False(t, nonNull)
+	require.IsType(t, RangeError{}, r.Error())
+
+	r = NewReader([]byte(`"x"`))
+	_ = r.Float32()
+	require.IsType(t, TypeError{}, r.Error())
+	require.False(t, r.Error().(TypeError).Nullable)
+
+	r = NewReader([]byte(`"x"`))
+	_, nonNull = r.Float32OrNull()
+	require.False(t, nonNull)
+	require.IsType(t, TypeError{}, r.Error())
+	require.True(t, r.Error().(TypeError).Nullable)
+}
+
 func TestReaderAddErrorDoesNotOverridePreviousError(t *testing.T) {
 	fakeError := errors.New("sorry")
 	r := NewReader([]byte(`"not a bool"`))
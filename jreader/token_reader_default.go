@@ -159,6 +159,40 @@ func (r *tokenReader) StringAsBytes() ([]byte, error) {
 	return t.stringValue, err
 }
 
+// StringContent requires that the next token is a JSON string, and consumes it without unescaping it.
+// It returns the bytes between the quote marks, with escaped set to true, or an error if the next
+// token is anything other than a JSON string; the caller is responsible for checking the escape
+// sequences. However, if the string was already parsed because it was put back with putBack, it
+// returns the unescaped value with escaped set to false.
+//
+// This and all other tokenReader methods skip transparently past whitespace between tokens.
+func (r *tokenReader) StringContent() (content []byte, escaped bool, err error) {
+	if r.hasUnread {
+		t, err := r.consumeScalar(stringToken)
+		return t.stringValue, false, err
+	}
+	b, ok := r.skipWhitespaceAndReadByte()
+	if !ok {
+		return nil, false, io.EOF
+	}
+	if b != '"' {
+		r.unreadByte()
+		_, err := r.consumeScalar(stringToken) // this will fail with the appropriate kind of error
+		return nil, false, err
+	}
+	start := r.pos
+	for i := start; i < r.len; i++ {
+		switch r.data[i] {
+		case '\\':
+			i++
+		case '"':
+			r.pos = i + 1
+			return r.data[start:i], true, nil
+		}
+	}
+	return nil, false, r.syntaxErrorOnLastToken(errMsgInvalidString)
+}
+
 // PropertyName requires that the next token is a JSON string and the token after that is a colon,
 // returning the string as a byte slice if successful, or an error otherwise.
 //
@@ -287,6 +321,16 @@ func (r *tokenReader) any(ignoreString bool) (AnyValue, error) {
 	}
 }
 
+// AnyWithNumberLiteral is the same as Any, except that if the value is a number, it also returns
+// the number's literal text as it appeared in the input.
+func (r *tokenReader) AnyWithNumberLiteral() (AnyValue, []byte, error) {
+	v, err := r.any(false)
+	if err != nil || v.Kind != NumberValue {
+		return v, nil, err
+	}
+	return v, r.data[r.lastPos:r.pos], nil
+}
+
 // Attempts to parse and consume the next token, ignoring whitespace. A token is either a valid JSON scalar
 // value or an ASCII delimiter character. If a token was previously unread using putBack, it consumes that
 // instead.
@@ -114,6 +114,19 @@ func (tr *tokenReader) String() (string, error) {
 	return "", tr.translateLexerErrorWithExpectedType(StringValue)
 }
 
+func (tr *tokenReader) StringContent() ([]byte, bool, error) {
+	pLexer := tr.pLexer
+	if pLexer == nil {
+		pLexer = &tr.inlineLexer
+	}
+	tr.markPosBeforeValue()
+	val := pLexer.UnsafeBytes()
+	if pLexer.Error() == nil {
+		return val, false, nil
+	}
+	return nil, false, tr.translateLexerErrorWithExpectedType(StringValue)
+}
+
 func (tr *tokenReader) PropertyName() ([]byte, error) {
 	pLexer := tr.pLexer
 	if pLexer == nil {
@@ -183,6 +196,56 @@ func (tr *tokenReader) any(_ bool) (AnyValue, error) {
 	return value, nil
 }
 
+func (tr *tokenReader) AnyWithNumberLiteral() (AnyValue, []byte, error) {
+	pLexer := tr.pLexer
+	if pLexer == nil {
+		pLexer = &tr.inlineLexer
+	}
+	// Lexer.Interface() would parse a number as a float64 and discard its text, so for scalar values
+	// we use Lexer.Raw() to get the text of the token, and then parse that separately. IsDelim can
+	// return a misleading true value if there's a parsing error, but in that case readAnyValue will
+	// return the error.
+	if pLexer.IsDelim('[') || pLexer.IsDelim('{') || pLexer.IsDelim(']') || pLexer.IsDelim('}') {
+		v, err := tr.any(false)
+		return v, nil, err
+	}
+	raw := pLexer.Raw()
+	if pLexer.Error() != nil {
+		return AnyValue{}, nil, tr.translateLexerError()
+	}
+	tempLexer := jlexer.Lexer{Data: raw}
+	value, err := readAnyValue(&tempLexer)
+	if err != nil {
+		return AnyValue{}, nil, translateLexerParseError(err) // COVERAGE: Raw() would already have failed
+	}
+	if value.Kind != NumberValue {
+		raw = nil
+	}
+	return value, raw, nil
+}
+
+func (tr *tokenReader) RawValue() ([]byte, error) {
+	pLexer := tr.pLexer
+	if pLexer == nil {
+		pLexer = &tr.inlineLexer
+	}
+	// Lexer.Raw() does not validate the contents of an array or object, so we parse it separately.
+	raw := pLexer.Raw()
+	if pLexer.Error() != nil {
+		return nil, tr.translateLexerError()
+	}
+	start := pLexer.GetPos() - len(raw)
+	tempReader := Reader{tr: newTokenReader(raw)}
+	if err := tempReader.SkipValue(); err != nil {
+		if se, ok := err.(SyntaxError); ok {
+			se.Offset += start
+			return nil, se
+		}
+		return nil, err // COVERAGE: SkipValue can only return a SyntaxError
+	}
+	return raw, nil
+}
+
 func (tr *tokenReader) lexerError() error {
 	if tr.pLexer == nil {
 		return tr.inlineLexer.Error()
@@ -0,0 +1,22 @@
+package jstream
+
+import (
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+// Copy reads the next JSON value of any type from the Reader, including all nested values within
+// an array or object, and writes it to the Writer.
+//
+// Numbers are written exactly as they appeared in the input, so no precision is lost. Strings,
+// including property names, have the same content, although characters may be escaped differently
+// in the output. Whitespace is not preserved; the output is formatted by the Writer.
+//
+// If there is a parsing error, the Reader enters a failed state, which you can detect with Error(),
+// and the same error is added to the Writer so that it stops producing output. The Writer may
+// already have received part of the value.
+//
+// This is the same as w.CopyFrom(r).
+func Copy(w *jwriter.Writer, r *jreader.Reader) {
+	w.CopyFrom(r)
+}
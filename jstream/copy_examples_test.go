@@ -0,0 +1,22 @@
+package jstream
+
+import (
+	"fmt"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+func ExampleCopy() {
+	r := jreader.NewReader([]byte(`{
+		"name": "x",
+		"value": 1.50
+	}`))
+	w := jwriter.NewWriter()
+	obj := w.Object()
+	obj.Name("id").Int(1)
+	Copy(obj.Name("payload"), &r)
+	obj.End()
+	fmt.Println(string(w.Bytes()), w.Error())
+	// Output: {"id":1,"payload":{"name":"x","value":1.50}} <nil>
+}
@@ -0,0 +1,85 @@
+package jstream
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopy(t *testing.T) {
+	for _, p := range []struct {
+		input    string
+		expected string
+	}{
+		{`null`, `null`},
+		{`true`, `true`},
+		{`false`, `false`},
+		{`0`, `0`},
+		{`-1.50`, `-1.50`},
+		{`1e+300`, `1e+300`},
+		{`12345678901234567890123456789`, `12345678901234567890123456789`},
+		{`""`, `""`},
+		{`"a\"b\\cé\n"`, "\"a\\\"b\\\\cé\\n\""},
+		{`[]`, `[]`},
+		{`{}`, `{}`},
+		{` [ 1 , [ 2.0 , [ ] ] , { } ] `, `[1,[2.0,[]],{}]`},
+		{`{ "a" : 1 , "b\"" : { "c" : [ null , "x" ] } }`, `{"a":1,"b\"":{"c":[null,"x"]}}`},
+		{`{"a": 1, "a": 2}`, `{"a":1,"a":2}`},
+	} {
+		t.Run(p.input, func(t *testing.T) {
+			r := jreader.NewReader([]byte(p.input))
+			w := jwriter.NewWriter()
+			Copy(&w, &r)
+			require.NoError(t, r.Error())
+			require.NoError(t, r.RequireEOF())
+			require.NoError(t, w.Error())
+			assert.Equal(t, p.expected, string(w.Bytes()))
+		})
+	}
+}
+
+func TestCopyIntoEnclosingValue(t *testing.T) {
+	r := jreader.NewReader([]byte(`[{"x": 1.0}, "y"]`))
+	w := jwriter.NewWriter()
+	obj := w.Object()
+	arr := r.Array()
+	for i := 0; arr.Next(); i++ {
+		Copy(obj.Name(string(rune('a'+i))), &r)
+	}
+	obj.End()
+	require.NoError(t, r.Error())
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":{"x":1.0},"b":"y"}`, string(w.Bytes()))
+}
+
+func TestCopyErrors(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`x`,
+		`{"a": x}`,
+		`[1, [x]]`,
+		`{"a": [1, {"b": }]}`,
+		`"abc`,
+	} {
+		t.Run(input, func(t *testing.T) {
+			r := jreader.NewReader([]byte(input))
+			w := jwriter.NewWriter()
+			Copy(&w, &r)
+			require.Error(t, r.Error())
+			assert.Equal(t, r.Error(), w.Error())
+		})
+	}
+
+	t.Run("reader already failed", func(t *testing.T) {
+		r := jreader.NewReader([]byte(`1`))
+		r.AddError(jreader.SyntaxError{Message: "x"})
+		w := jwriter.NewWriter()
+		Copy(&w, &r)
+		assert.Equal(t, r.Error(), w.Error())
+		assert.Len(t, w.Bytes(), 0)
+	})
+}
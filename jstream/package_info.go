@@ -0,0 +1,16 @@
+// Package jstream provides operations that connect a jreader.Reader to a jwriter.Writer.
+//
+// Copy transfers a JSON value from a Reader to a Writer one token at a time, without building any
+// intermediate representation of it. This can be used to minify JSON, to validate it while copying
+// it, or to splice a JSON value from one document into another that is being written:
+//
+//	r := jreader.NewReader(input)
+//	w := jwriter.NewWriter()
+//	obj := w.Object()
+//	obj.Name("id").String(id)
+//	jstream.Copy(obj.Name("payload"), &r)
+//	obj.End()
+//	if err := w.Error(); err != nil {
+//	    // the input was malformed
+//	}
+package jstream
@@ -0,0 +1,23 @@
+// Package jvalue provides an immutable representation of arbitrary JSON values.
+//
+// A Value can be any JSON type: null, boolean, number, string, array, or object. Objects preserve
+// the order of their properties. Values can be parsed from a jreader.Reader, written to a
+// jwriter.Writer, compared with Equal, and navigated with JSON Pointer expressions.
+//
+//	v, err := jvalue.Parse([]byte(`{"items": [{"key": "a"}, {"key": "b"}]}`))
+//	if err != nil {
+//	    return err
+//	}
+//	key, found := v.Find("/items/1/key") // returns jvalue.String("b"), true
+//
+// Arrays and objects are built with ArrayBuilder and ObjectBuilder:
+//
+//	v := jvalue.NewObjectBuilder().
+//	    Set("name", jvalue.String("x")).
+//	    Set("tags", jvalue.ArrayOf(jvalue.String("a"), jvalue.String("b"))).
+//	    Build()
+//
+// Since a Value cannot be modified after it is created, it is safe to share between goroutines.
+// Scalar values (null, boolean, number, and string) are represented without any heap allocation
+// other than the string itself.
+package jvalue
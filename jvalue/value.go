@@ -0,0 +1,196 @@
+package jvalue
+
+import (
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+)
+
+// Value is an immutable representation of any JSON value.
+//
+// The zero value of Value is a JSON null. Other values are created with the constructors Bool,
+// Number, Int, String, and ArrayOf; with ArrayBuilder or ObjectBuilder; or by parsing JSON with
+// Parse or Read.
+//
+// Value is designed to be passed by value. Scalar values do not refer to any heap data other than
+// a string, so they can be created without allocation. Array and object values refer to an
+// internal slice that is never modified after the Value is created.
+type Value struct {
+	kind    jreader.ValueKind
+	boolean bool
+	number  float64
+
+	// text is the value of a string, or the original literal text of a number if it was parsed
+	// from JSON; in the latter case we use it when writing the value, so that it is reproduced
+	// exactly.
+	text string
+
+	// values contains the elements of an array, or the property values of an object.
+	values []Value
+
+	// names contains the property names of an object, in the same order as values.
+	names []string
+
+	// index maps the property names of an object to their positions, if the object has more than
+	// indexThreshold properties; otherwise it is nil.
+	index map[string]int
+}
+
+// Null returns a JSON null value. This is the same as the zero value of Value.
+func Null() Value {
+	return Value{}
+}
+
+// Bool returns a JSON boolean value.
+func Bool(value bool) Value {
+	return Value{kind: jreader.BoolValue, boolean: value}
+}
+
+// Number returns a JSON numeric value.
+func Number(value float64) Value {
+	return Value{kind: jreader.NumberValue, number: value}
+}
+
+// Int returns a JSON numeric value from an int. This is a shortcut for Number(float64(value)).
+func Int(value int) Value {
+	return Number(float64(value))
+}
+
+// String returns a JSON string value.
+func String(value string) Value {
+	return Value{kind: jreader.StringValue, text: value}
+}
+
+// ArrayOf returns a JSON array value containing the specified elements. The slice is copied, so
+// modifying it afterward does not affect the Value.
+func ArrayOf(values ...Value) Value {
+	return Value{kind: jreader.ArrayValue, values: append([]Value(nil), values...)}
+}
+
+// Kind returns the type of the JSON value.
+func (v Value) Kind() jreader.ValueKind {
+	return v.kind
+}
+
+// IsNull returns true if the value is a JSON null.
+func (v Value) IsNull() bool {
+	return v.kind == jreader.NullValue
+}
+
+// BoolValue returns the value if it is a JSON boolean, or false otherwise.
+func (v Value) BoolValue() bool {
+	return v.boolean
+}
+
+// Float64Value returns the value if it is a JSON number, or zero otherwise.
+func (v Value) Float64Value() float64 {
+	return v.number
+}
+
+// IntValue returns the value if it is a JSON number, converted to an int in the same way as
+// jreader.Reader.Int, or zero otherwise.
+func (v Value) IntValue() int {
+	return int(v.number)
+}
+
+// StringValue returns the value if it is a JSON string, or an empty string otherwise.
+//
+// This is different from String, which returns the JSON representation of any value.
+func (v Value) StringValue() string {
+	if v.kind != jreader.StringValue {
+		return ""
+	}
+	return v.text
+}
+
+// Len returns the number of elements if the value is a JSON array, or the number of properties if
+// it is a JSON object, or zero otherwise.
+func (v Value) Len() int {
+	return len(v.values)
+}
+
+// Index returns an element of a JSON array. If the value is not an array, or the index is out of
+// range, it returns a null.
+func (v Value) Index(index int) Value {
+	if v.kind != jreader.ArrayValue || index < 0 || index >= len(v.values) {
+		return Value{}
+	}
+	return v.values[index]
+}
+
+// Member returns the name and value of a property of a JSON object, by its position in the object.
+// If the value is not an object, or the index is out of range, it returns an empty name and a null.
+func (v Value) Member(index int) (string, Value) {
+	if v.kind != jreader.ObjectValue || index < 0 || index >= len(v.values) {
+		return "", Value{}
+	}
+	return v.names[index], v.values[index]
+}
+
+// Get returns the value of a property of a JSON object, and true if the property exists. If the
+// value is not an object, or there is no such property, it returns a null and false.
+//
+// For a small object, this is a linear search, which is efficient for the small objects that are
+// typical of JSON data; a larger object has a map index of its property names.
+func (v Value) Get(name string) (Value, bool) {
+	if v.kind == jreader.ObjectValue {
+		if i, ok := findProperty(v.names, v.index, name); ok {
+			return v.values[i], true
+		}
+	}
+	return Value{}, false
+}
+
+// Keys returns the property names of a JSON object in their original order, or nil if the value
+// is not an object. The returned slice is a copy.
+func (v Value) Keys() []string {
+	if v.kind != jreader.ObjectValue {
+		return nil
+	}
+	return append([]string{}, v.names...)
+}
+
+// Equal returns true if two values are deeply equal: they have the same type, and the same value
+// for a scalar type, or the same elements in the same order for an array, or the same property
+// names and values regardless of order for an object. Numbers are compared by their numeric value,
+// so 1 and 1.0 are equal.
+func (v Value) Equal(other Value) bool {
+	if v.kind != other.kind {
+		return false
+	}
+	switch v.kind {
+	case jreader.BoolValue:
+		return v.boolean == other.boolean
+	case jreader.NumberValue:
+		return v.number == other.number
+	case jreader.StringValue:
+		return v.text == other.text
+	case jreader.ArrayValue:
+		if len(v.values) != len(other.values) {
+			return false
+		}
+		for i, element := range v.values {
+			if !element.Equal(other.values[i]) {
+				return false
+			}
+		}
+		return true
+	case jreader.ObjectValue:
+		if len(v.values) != len(other.values) {
+			return false
+		}
+		for i, name := range v.names {
+			otherValue, ok := other.Get(name)
+			if !ok || !v.values[i].Equal(otherValue) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// String returns the JSON representation of the value. This allows a Value to be used with
+// fmt.Print and similar functions.
+func (v Value) String() string {
+	return string(v.JSONBytes())
+}
@@ -0,0 +1,137 @@
+package jvalue
+
+import "github.com/launchdarkly/go-jsonstream/v3/jreader"
+
+// ArrayBuilder is used to create a JSON array value one element at a time.
+//
+//	v := jvalue.NewArrayBuilder().Add(jvalue.Int(1)).Add(jvalue.Int(2)).Build()
+//
+// A builder can continue to be used after Build is called; each Value that it builds is
+// independent of subsequent changes.
+type ArrayBuilder struct {
+	values []Value
+}
+
+// ObjectBuilder is used to create a JSON object value one property at a time. Properties appear
+// in the object in the order that they were first set.
+//
+//	v := jvalue.NewObjectBuilder().Set("a", jvalue.Int(1)).Set("b", jvalue.Int(2)).Build()
+//
+// A builder can continue to be used after Build is called; each Value that it builds is
+// independent of subsequent changes.
+type ObjectBuilder struct {
+	props properties
+}
+
+// properties holds the members of an object while it is being built.
+type properties struct {
+	names  []string
+	values []Value
+	index  map[string]int // see indexThreshold
+}
+
+// indexThreshold is the number of properties above which an object also has a map from property
+// names to positions, so that looking up a property, or detecting a duplicate name while the object
+// is being built, does not require a linear search. Small objects, which are the most common in JSON
+// data, do not need the extra allocation.
+const indexThreshold = 16
+
+// NewArrayBuilder creates an ArrayBuilder.
+func NewArrayBuilder() *ArrayBuilder {
+	return &ArrayBuilder{}
+}
+
+// Add appends an element to the array.
+func (b *ArrayBuilder) Add(value Value) *ArrayBuilder {
+	b.values = append(b.values, value)
+	return b
+}
+
+// Build returns a JSON array value containing the elements that have been added so far.
+func (b *ArrayBuilder) Build() Value {
+	return ArrayOf(b.values...)
+}
+
+// NewObjectBuilder creates an ObjectBuilder.
+func NewObjectBuilder() *ObjectBuilder {
+	return &ObjectBuilder{}
+}
+
+// Set adds a property to the object. If a property with the same name was already set, its value
+// is replaced, but it keeps its original position.
+func (b *ObjectBuilder) Set(name string, value Value) *ObjectBuilder {
+	b.props.set(name, value)
+	return b
+}
+
+// Remove removes a property from the object, if it was previously set.
+func (b *ObjectBuilder) Remove(name string) *ObjectBuilder {
+	p := &b.props
+	if i, ok := findProperty(p.names, p.index, name); ok {
+		p.names = append(p.names[:i], p.names[i+1:]...)
+		p.values = append(p.values[:i], p.values[i+1:]...)
+		if p.index != nil {
+			delete(p.index, name)
+			for j := i; j < len(p.names); j++ {
+				p.index[p.names[j]] = j
+			}
+		}
+	}
+	return b
+}
+
+// Build returns a JSON object value containing the properties that have been set so far.
+func (b *ObjectBuilder) Build() Value {
+	names := append([]string(nil), b.props.names...)
+	var index map[string]int
+	if b.props.index != nil {
+		index = makeIndex(names)
+	}
+	return Value{
+		kind:   jreader.ObjectValue,
+		names:  names,
+		values: append([]Value(nil), b.props.values...),
+		index:  index,
+	}
+}
+
+// set adds a property, or replaces the value of an existing property with the same name.
+func (p *properties) set(name string, value Value) {
+	if i, ok := findProperty(p.names, p.index, name); ok {
+		p.values[i] = value
+		return
+	}
+	p.names = append(p.names, name)
+	p.values = append(p.values, value)
+	if p.index != nil {
+		p.index[name] = len(p.names) - 1
+	} else if len(p.names) > indexThreshold {
+		p.index = makeIndex(p.names)
+	}
+}
+
+// object returns an object Value that takes ownership of the properties.
+func (p *properties) object() Value {
+	return Value{kind: jreader.ObjectValue, names: p.names, values: p.values, index: p.index}
+}
+
+func findProperty(names []string, index map[string]int, name string) (int, bool) {
+	if index != nil {
+		i, ok := index[name]
+		return i, ok
+	}
+	for i, n := range names {
+		if n == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func makeIndex(names []string) map[string]int {
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+	}
+	return index
+}
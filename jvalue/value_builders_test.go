@@ -0,0 +1,73 @@
+package jvalue
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayBuilder(t *testing.T) {
+	b := NewArrayBuilder().Add(Int(1)).Add(Int(2))
+	v1 := b.Build()
+	b.Add(Int(3))
+	v2 := b.Build()
+
+	assert.Equal(t, `[1,2]`, v1.String())
+	assert.Equal(t, `[1,2,3]`, v2.String())
+	assert.Equal(t, `[]`, NewArrayBuilder().Build().String())
+}
+
+func TestObjectBuilder(t *testing.T) {
+	t.Run("properties keep their original order", func(t *testing.T) {
+		v := NewObjectBuilder().Set("b", Int(1)).Set("a", Int(2)).Set("b", Int(3)).Build()
+		assert.Equal(t, `{"b":3,"a":2}`, v.String())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		v := NewObjectBuilder().Set("a", Int(1)).Set("b", Int(2)).Set("c", Int(3)).Remove("b").Remove("x").Build()
+		assert.Equal(t, `{"a":1,"c":3}`, v.String())
+	})
+
+	t.Run("built values are not affected by later changes", func(t *testing.T) {
+		b := NewObjectBuilder().Set("a", Int(1))
+		v1 := b.Build()
+		b.Set("a", Int(2)).Set("b", Int(3))
+		v2 := b.Build()
+		b.Remove("a")
+		assert.Equal(t, `{"a":1}`, v1.String())
+		assert.Equal(t, `{"a":2,"b":3}`, v2.String())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, `{}`, NewObjectBuilder().Build().String())
+	})
+
+	t.Run("large object", func(t *testing.T) {
+		b := NewObjectBuilder()
+		for i := 0; i < indexThreshold*2; i++ {
+			b.Set("p"+strconv.Itoa(i), Int(i))
+		}
+		b.Set("p1", String("x")).Remove("p0").Remove("p5").Set("p0", Int(0))
+		v := b.Build()
+		b.Remove("p2").Set("p3", Null())
+
+		assert.Equal(t, indexThreshold*2-1, v.Len())
+		name, value := v.Member(0)
+		assert.Equal(t, "p1", name)
+		assert.Equal(t, String("x"), value)
+		name, _ = v.Member(v.Len() - 1)
+		assert.Equal(t, "p0", name)
+		for i := 0; i < indexThreshold*2; i++ {
+			value, ok := v.Get("p" + strconv.Itoa(i))
+			switch i {
+			case 1:
+				assert.Equal(t, String("x"), value)
+			case 5:
+				assert.False(t, ok)
+			default:
+				assert.Equal(t, Int(i), value)
+			}
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package jvalue
+
+import "fmt"
+
+func ExampleParse() {
+	v, err := Parse([]byte(`{"items": [{"key": "a"}, {"key": "b"}]}`))
+	fmt.Println(v, err)
+	// Output: {"items":[{"key":"a"},{"key":"b"}]} <nil>
+}
+
+func ExampleValue_Find() {
+	v, _ := Parse([]byte(`{"items": [{"key": "a"}, {"key": "b"}]}`))
+	key, found := v.Find("/items/1/key")
+	fmt.Println(key.StringValue(), found)
+	// Output: b true
+}
+
+func ExampleObjectBuilder() {
+	v := NewObjectBuilder().
+		Set("name", String("x")).
+		Set("tags", ArrayOf(String("a"), String("b"))).
+		Build()
+	fmt.Println(v)
+	// Output: {"name":"x","tags":["a","b"]}
+}
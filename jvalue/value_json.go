@@ -0,0 +1,125 @@
+package jvalue
+
+import (
+	"encoding/json"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+)
+
+// Parse parses a Value from JSON data. The data must contain exactly one JSON value, optionally
+// surrounded by whitespace.
+func Parse(data []byte) (Value, error) {
+	r := jreader.NewReader(data)
+	v := Read(&r)
+	if err := r.Error(); err != nil {
+		return Value{}, err
+	}
+	if err := r.RequireEOF(); err != nil {
+		return Value{}, err
+	}
+	return v, nil
+}
+
+// Read reads a Value of any JSON type from a Reader, including all nested values within an array
+// or object. If a property name appears more than once in an object, the last value wins, but the
+// property keeps the position where it first appeared.
+//
+// The original text of each number is retained, so that it is reproduced exactly when the Value is
+// written, even if it has more precision than a float64 can represent.
+//
+// If there is a parsing error, the return value is a null and the Reader enters a failed state,
+// which you can detect with Error().
+func Read(r *jreader.Reader) Value {
+	v := readValue(r)
+	if r.Error() != nil {
+		return Value{}
+	}
+	return v
+}
+
+func readValue(r *jreader.Reader) Value {
+	value, literal := r.AnyWithNumberLiteral()
+	switch value.Kind {
+	case jreader.BoolValue:
+		return Bool(value.Bool)
+	case jreader.NumberValue:
+		return Value{kind: jreader.NumberValue, number: value.Number, text: string(literal)}
+	case jreader.StringValue:
+		return String(value.String)
+	case jreader.ArrayValue:
+		var values []Value
+		for value.Array.Next() {
+			values = append(values, readValue(r))
+		}
+		return Value{kind: jreader.ArrayValue, values: values}
+	case jreader.ObjectValue:
+		var props properties
+		for value.Object.Next() {
+			name := string(value.Object.Name())
+			props.set(name, readValue(r))
+		}
+		return props.object()
+	default:
+		return Value{}
+	}
+}
+
+// ReadFromJSONReader provides JSON deserialization for use with the jsonstream API. It replaces
+// the Value with the result of Read.
+//
+// This implementation is used by UnmarshalJSON; it is also called automatically if a Value is
+// read with jreader.ReadInto or with generated code.
+func (v *Value) ReadFromJSONReader(r *jreader.Reader) {
+	*v = Read(r)
+}
+
+// WriteToJSONWriter provides JSON serialization for use with the jsonstream API.
+//
+// This implementation is used by MarshalJSON; it is also called automatically if a Value is
+// written with jwriter.WriteValue or with generated code.
+func (v Value) WriteToJSONWriter(w *jwriter.Writer) {
+	switch v.kind {
+	case jreader.BoolValue:
+		w.Bool(v.boolean)
+	case jreader.NumberValue:
+		if v.text != "" {
+			w.Raw(json.RawMessage(v.text))
+		} else {
+			w.Float64(v.number)
+		}
+	case jreader.StringValue:
+		w.String(v.text)
+	case jreader.ArrayValue:
+		arr := w.Array()
+		for _, element := range v.values {
+			element.WriteToJSONWriter(w)
+		}
+		arr.End()
+	case jreader.ObjectValue:
+		obj := w.Object()
+		for i, name := range v.names {
+			v.values[i].WriteToJSONWriter(obj.Name(name))
+		}
+		obj.End()
+	default:
+		w.Null()
+	}
+}
+
+// MarshalJSON converts the Value to its JSON representation.
+func (v Value) MarshalJSON() ([]byte, error) {
+	return jwriter.MarshalJSONWithWriter(v)
+}
+
+// UnmarshalJSON parses a Value from JSON.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	return jreader.UnmarshalJSONWithReader(data, v)
+}
+
+// JSONBytes returns the JSON representation of the value as a byte slice.
+func (v Value) JSONBytes() []byte {
+	w := jwriter.NewWriter()
+	v.WriteToJSONWriter(&w)
+	return w.Bytes()
+}
@@ -0,0 +1,121 @@
+package jvalue
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+	"github.com/launchdarkly/go-jsonstream/v3/jwriter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		for _, p := range []struct {
+			json     string
+			expected Value
+		}{
+			{`null`, Null()},
+			{`true`, Bool(true)},
+			{`-1.5`, Number(-1.5)},
+			{`"a\"b"`, String(`a"b`)},
+			{`[]`, ArrayOf()},
+			{`[1, [null]]`, ArrayOf(Int(1), ArrayOf(Null()))},
+			{`{"b": {}, "a": "x"}`, NewObjectBuilder().Set("b", NewObjectBuilder().Build()).Set("a", String("x")).Build()},
+		} {
+			t.Run(p.json, func(t *testing.T) {
+				v, err := Parse([]byte(p.json))
+				require.NoError(t, err)
+				assert.True(t, p.expected.Equal(v), "expected %s, got %s", p.expected, v)
+			})
+		}
+	})
+
+	t.Run("duplicate property names", func(t *testing.T) {
+		v, err := Parse([]byte(`{"a": 1, "b": 2, "a": 3}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":3,"b":2}`, v.String())
+
+		input := `{"a": 1`
+		for i := 0; i < indexThreshold; i++ {
+			input += `, "p` + strconv.Itoa(i) + `": 0`
+		}
+		v, err = Parse([]byte(input + `, "a": 2}`))
+		require.NoError(t, err)
+		assert.Equal(t, indexThreshold+1, v.Len())
+		name, value := v.Member(0)
+		assert.Equal(t, "a", name)
+		assert.True(t, Int(2).Equal(value))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, s := range []string{``, `{`, `{"a": x}`, `1 2`, `{"a" 1}`} {
+			v, err := Parse([]byte(s))
+			assert.Error(t, err, s)
+			assert.Equal(t, Null(), v)
+		}
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("number literals are preserved", func(t *testing.T) {
+		data := `[1.0,1e2,12345678901234567890,-0]`
+		v, err := Parse([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, data, v.String())
+	})
+
+	t.Run("constructed numbers", func(t *testing.T) {
+		assert.Equal(t, `[1,1.5,-2]`, ArrayOf(Int(1), Number(1.5), Int(-2)).String())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		data := `{"z":[true,false,null,"x\n"],"a":{"b":{}},"n":3}`
+		v, err := Parse([]byte(data))
+		require.NoError(t, err)
+		assert.Equal(t, data, v.String())
+		assert.Equal(t, data, string(v.JSONBytes()))
+	})
+
+	t.Run("within other data", func(t *testing.T) {
+		v := ArrayOf(String("a"))
+		w := jwriter.NewWriter()
+		obj := w.Object()
+		v.WriteToJSONWriter(obj.Name("value"))
+		obj.End()
+		assert.Equal(t, `{"value":["a"]}`, string(w.Bytes()))
+	})
+}
+
+func TestReadFromJSONReader(t *testing.T) {
+	r := jreader.NewReader([]byte(`{"value": [1, 2], "other": true}`))
+	var v Value
+	for obj := r.Object(); obj.Next(); {
+		if string(obj.Name()) == "value" {
+			v.ReadFromJSONReader(&r)
+		}
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, `[1,2]`, v.String())
+
+	r = jreader.NewReader([]byte(`[1, [x]]`))
+	assert.Equal(t, Null(), Read(&r))
+	assert.Error(t, r.Error())
+}
+
+func TestEncodingJSONCompatibility(t *testing.T) {
+	var s struct {
+		Value Value `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"value": {"a": [1.50]}}`), &s))
+	assert.Equal(t, `{"a":[1.50]}`, s.Value.String())
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `{"value":{"a":[1.50]}}`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"value": [}`), &s))
+}
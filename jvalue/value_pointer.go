@@ -0,0 +1,44 @@
+package jvalue
+
+import (
+	"github.com/launchdarkly/go-jsonstream/v3/jpointer"
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+)
+
+// Find returns the value that a JSON Pointer (RFC 6901) refers to within this value, and true if
+// it exists. An empty string refers to the whole value; "/items/0/key" refers to the "key"
+// property of the first element of the "items" property.
+//
+// If the pointer is not syntactically valid, or does not refer to an existing value, it returns a
+// null and false. To distinguish between those cases, use jpointer.Parse and FindPointer.
+func (v Value) Find(pointer string) (Value, bool) {
+	p, err := jpointer.Parse(pointer)
+	if err != nil {
+		return Value{}, false
+	}
+	return v.FindPointer(p)
+}
+
+// FindPointer is the same as Find, but takes a pointer that has already been parsed.
+func (v Value) FindPointer(pointer jpointer.Pointer) (Value, bool) {
+	current := v
+	for i := 0; i < pointer.Len(); i++ {
+		token := pointer.Token(i)
+		switch current.kind {
+		case jreader.ArrayValue:
+			index, ok := jpointer.ArrayIndex(token)
+			if !ok || index >= len(current.values) {
+				return Value{}, false
+			}
+			current = current.values[index]
+		case jreader.ObjectValue:
+			var ok bool
+			if current, ok = current.Get(token); !ok {
+				return Value{}, false
+			}
+		default:
+			return Value{}, false
+		}
+	}
+	return current, true
+}
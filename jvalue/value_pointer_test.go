@@ -0,0 +1,83 @@
+package jvalue
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jpointer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	// This is the example document from RFC 6901, section 5.
+	doc, err := Parse([]byte(`{
+		"foo": ["bar", "baz"],
+		"": 0,
+		"a/b": 1,
+		"c%d": 2,
+		"e^f": 3,
+		"g|h": 4,
+		"i\\j": 5,
+		"k\"l": 6,
+		" ": 7,
+		"m~n": 8
+	}`))
+	require.NoError(t, err)
+
+	for _, p := range []struct {
+		pointer  string
+		expected Value
+	}{
+		{``, doc},
+		{`/foo`, ArrayOf(String("bar"), String("baz"))},
+		{`/foo/0`, String("bar")},
+		{`/`, Int(0)},
+		{`/a~1b`, Int(1)},
+		{`/c%d`, Int(2)},
+		{`/e^f`, Int(3)},
+		{`/g|h`, Int(4)},
+		{`/i\j`, Int(5)},
+		{`/k"l`, Int(6)},
+		{`/ `, Int(7)},
+		{`/m~0n`, Int(8)},
+	} {
+		t.Run(p.pointer, func(t *testing.T) {
+			v, found := doc.Find(p.pointer)
+			assert.True(t, found)
+			assert.True(t, p.expected.Equal(v), "expected %s, got %s", p.expected, v)
+		})
+	}
+
+	for _, pointer := range []string{
+		`foo`,       // does not start with a slash
+		`/bar`,      // no such property
+		`/foo/2`,    // index out of range
+		`/foo/-`,    // refers to the element after the end
+		`/foo/01`,   // leading zeroes not allowed
+		`/foo/-1`,   // negative index
+		`/foo/x`,    // not an index
+		`/foo/0/x`,  // cannot descend into a string
+		`/m~2n`,     // invalid escape
+		`/m~`,       // incomplete escape
+		`/foo/1e0`,  // not an index
+		`/foo/ 1`,   // not an index
+		`/foo/0/0/`, // cannot descend into a string
+	} {
+		t.Run(pointer, func(t *testing.T) {
+			v, found := doc.Find(pointer)
+			assert.False(t, found)
+			assert.Equal(t, Null(), v)
+		})
+	}
+}
+
+func TestFindPointer(t *testing.T) {
+	doc := NewObjectBuilder().Set("a/b", ArrayOf(Int(1), Int(2))).Build()
+	v, found := doc.FindPointer(jpointer.New("a/b", "1"))
+	assert.True(t, found)
+	assert.Equal(t, Int(2), v)
+
+	_, found = doc.FindPointer(jpointer.New("a", "b"))
+	assert.False(t, found)
+}
@@ -0,0 +1,129 @@
+package jvalue
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalarValues(t *testing.T) {
+	assert.Equal(t, jreader.NullValue, Value{}.Kind())
+	assert.True(t, Null().IsNull())
+	assert.Equal(t, Null(), Value{})
+
+	assert.Equal(t, jreader.BoolValue, Bool(true).Kind())
+	assert.True(t, Bool(true).BoolValue())
+	assert.False(t, Bool(true).IsNull())
+
+	assert.Equal(t, jreader.NumberValue, Number(1.5).Kind())
+	assert.Equal(t, 1.5, Number(1.5).Float64Value())
+	assert.Equal(t, 1, Number(1.5).IntValue())
+	assert.Equal(t, Number(3), Int(3))
+
+	assert.Equal(t, jreader.StringValue, String("x").Kind())
+	assert.Equal(t, "x", String("x").StringValue())
+
+	// accessors for the wrong type return zero values
+	assert.False(t, String("true").BoolValue())
+	assert.Equal(t, float64(0), String("1").Float64Value())
+	assert.Equal(t, "", Int(1).StringValue())
+	assert.Equal(t, 0, String("abc").Len())
+}
+
+func TestScalarValuesDoNotAllocate(t *testing.T) {
+	s := "abc"
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, v := range []Value{Null(), Bool(true), Number(1.5), Int(2), String(s)} {
+			_ = v.Kind()
+		}
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestArrayValue(t *testing.T) {
+	elements := []Value{Int(1), String("a")}
+	v := ArrayOf(elements...)
+	elements[0] = Int(99) // does not affect the Value
+
+	assert.Equal(t, jreader.ArrayValue, v.Kind())
+	assert.Equal(t, 2, v.Len())
+	assert.Equal(t, Int(1), v.Index(0))
+	assert.Equal(t, String("a"), v.Index(1))
+	assert.Equal(t, Null(), v.Index(2))
+	assert.Equal(t, Null(), v.Index(-1))
+
+	empty := ArrayOf()
+	assert.Equal(t, jreader.ArrayValue, empty.Kind())
+	assert.Equal(t, 0, empty.Len())
+}
+
+func TestObjectValue(t *testing.T) {
+	v := NewObjectBuilder().Set("b", Int(1)).Set("a", Int(2)).Build()
+
+	assert.Equal(t, jreader.ObjectValue, v.Kind())
+	assert.Equal(t, 2, v.Len())
+	assert.Equal(t, []string{"b", "a"}, v.Keys())
+
+	a, ok := v.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, Int(2), a)
+	_, ok = v.Get("c")
+	assert.False(t, ok)
+
+	name, value := v.Member(0)
+	assert.Equal(t, "b", name)
+	assert.Equal(t, Int(1), value)
+	name, value = v.Member(2)
+	assert.Equal(t, "", name)
+	assert.Equal(t, Null(), value)
+
+	// object accessors don't work on arrays and vice versa
+	assert.Equal(t, Null(), v.Index(0))
+	assert.Nil(t, ArrayOf(String("a")).Keys())
+	_, ok = ArrayOf(String("a")).Get("0")
+	assert.False(t, ok)
+}
+
+func TestEqual(t *testing.T) {
+	values := []Value{
+		Null(),
+		Bool(false),
+		Bool(true),
+		Int(0),
+		Int(1),
+		String(""),
+		String("1"),
+		ArrayOf(),
+		ArrayOf(Int(1)),
+		ArrayOf(Int(1), Int(2)),
+		ArrayOf(Int(2), Int(1)),
+		NewObjectBuilder().Build(),
+		NewObjectBuilder().Set("a", Int(1)).Build(),
+		NewObjectBuilder().Set("a", Int(2)).Build(),
+		NewObjectBuilder().Set("a", Int(1)).Set("b", Int(2)).Build(),
+	}
+	for i, v1 := range values {
+		for j, v2 := range values {
+			assert.Equal(t, i == j, v1.Equal(v2), "%s, %s", v1, v2)
+		}
+	}
+
+	obj1 := NewObjectBuilder().Set("a", Int(1)).Set("b", ArrayOf(Bool(true))).Build()
+	obj2 := NewObjectBuilder().Set("b", ArrayOf(Bool(true))).Set("a", Number(1.0)).Build()
+	assert.True(t, obj1.Equal(obj2), "property order does not matter")
+
+	parsed, _ := Parse([]byte(`1.0`))
+	assert.True(t, parsed.Equal(Int(1)), "number format does not matter")
+
+	forward, backward, different := NewObjectBuilder(), NewObjectBuilder(), NewObjectBuilder()
+	for i := 0; i < indexThreshold*2; i++ {
+		forward.Set(strconv.Itoa(i), Int(i))
+		backward.Set(strconv.Itoa(indexThreshold*2-1-i), Int(indexThreshold*2-1-i))
+		different.Set(strconv.Itoa(i+1), Int(i+1))
+	}
+	assert.True(t, forward.Build().Equal(backward.Build()), "property order does not matter in large objects")
+	assert.False(t, forward.Build().Equal(different.Build()))
+}
@@ -0,0 +1,54 @@
+package jwriter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// UnsupportedTypeError is returned by WriteValue if the type of some value cannot be represented
+// in JSON, such as a channel or a function.
+type UnsupportedTypeError struct {
+	// Type is the Go type that could not be written.
+	Type reflect.Type
+}
+
+// Error returns a description of the error.
+func (e UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("cannot write a value of Go type %s as JSON", e.Type)
+}
+
+// CycleError is returned by WriteValue if a value contains a reference to itself, through pointers,
+// maps, or slices, so that writing it would never end.
+type CycleError struct {
+	// Type is the Go type of the value that was reached a second time.
+	Type reflect.Type
+}
+
+// Error returns a description of the error.
+func (e CycleError) Error() string {
+	return fmt.Sprintf("cannot write a cyclic value of Go type %s as JSON", e.Type)
+}
+
+// UnsupportedValueError is returned if a value cannot be represented in the output, such as a NaN
+// or infinite number.
+type UnsupportedValueError struct {
+	// Value is the value that could not be written.
+	Value interface{}
+}
+
+// Error returns a description of the error.
+func (e UnsupportedValueError) Error() string {
+	return fmt.Sprintf("cannot write the value %v as JSON", e.Value)
+}
+
+// StructureError is returned if the sequence of Writer operations would produce malformed JSON,
+// when the Writer is in strict mode (see Writer.SetStrict), or by Writer.Finish.
+type StructureError struct {
+	// Message is a description of the problem.
+	Message string
+}
+
+// Error returns a description of the error.
+func (e StructureError) Error() string {
+	return "invalid JSON structure: " + e.Message
+}
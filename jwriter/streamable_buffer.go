@@ -16,6 +16,10 @@ func (b *streamableBuffer) Bytes() []byte {
 	return b.buf.Bytes()
 }
 
+func (b *streamableBuffer) Len() int {
+	return b.buf.Len()
+}
+
 func (b *streamableBuffer) Grow(n int) {
 	b.buf.Grow(n)
 }
@@ -20,6 +20,8 @@ var (
 	tokenNull  = []byte("null")  //nolint:gochecknoglobals
 	tokenTrue  = []byte("true")  //nolint:gochecknoglobals
 	tokenFalse = []byte("false") //nolint:gochecknoglobals
+
+	escapedReplacementChar = []byte(`\ufffd`) //nolint:gochecknoglobals
 )
 
 type tokenWriter struct {
@@ -38,6 +40,17 @@ func newStreamingTokenWriter(dest io.Writer, bufferSize int) tokenWriter {
 	return tw
 }
 
+// newTemporary creates a non-streaming tokenWriter with the same encoding behavior as this one,
+// for buffering part of the output.
+func (tw *tokenWriter) newTemporary() tokenWriter {
+	return newTokenWriter()
+}
+
+// stringEscaping returns the StringEscaping flags that describe which characters String escapes.
+func (tw *tokenWriter) stringEscaping() StringEscaping {
+	return EscapeMinimal
+}
+
 // Bytes returns the full encoded byte slice.
 //
 // If the buffer is in a failed state from a previous invalid operation, Bytes() returns any data written
@@ -46,6 +59,11 @@ func (tw *tokenWriter) Bytes() []byte {
 	return tw.buf.Bytes()
 }
 
+// Size returns the number of bytes in the buffer that have not yet been flushed.
+func (tw *tokenWriter) Size() int {
+	return tw.buf.Len()
+}
+
 // Grow expands the internal buffer by the specified number of bytes. It is the same as calling Grow
 // on a bytes.Buffer.
 func (tw *tokenWriter) Grow(n int) {
@@ -88,14 +106,46 @@ func (tw *tokenWriter) Int(value int) error {
 	return tw.buf.GetWriterError()
 }
 
-// Float64 writes a JSON number.
+// Int64 writes an integer JSON number.
+func (tw *tokenWriter) Int64(value int64) error {
+	out := tw.tempBytes[0:0]
+	out = strconv.AppendInt(out, value, 10)
+	tw.buf.Write(out)
+	return tw.buf.GetWriterError()
+}
+
+// Uint64 writes a non-negative integer JSON number.
+func (tw *tokenWriter) Uint64(value uint64) error {
+	out := tw.tempBytes[0:0]
+	out = strconv.AppendUint(out, value, 10)
+	tw.buf.Write(out)
+	return tw.buf.GetWriterError()
+}
+
+// Float32 is the same as Float64, except that it uses the fewest digits that represent the same
+// float32 value.
+func (tw *tokenWriter) Float32(value float32) error {
+	if value == 0 {
+		tw.buf.WriteByte('0')
+	} else {
+		if i, ok := floatToInt64(float64(value)); ok {
+			return tw.Int64(i)
+		}
+		out := tw.tempBytes[0:0]
+		out = strconv.AppendFloat(out, float64(value), 'g', -1, 32)
+		tw.buf.Write(out)
+	}
+	return tw.buf.GetWriterError()
+}
+
+// Float64 writes a JSON number. Integral values within the range of int64 are written as integers;
+// others use the shortest representation, with an exponent for very large or small values.
 func (tw *tokenWriter) Float64(value float64) error {
 	if value == 0 {
 		tw.buf.WriteByte('0')
 	} else {
-		i := int(value)
-		if float64(i) == value {
-			return tw.Int(i)
+		if i, ok := floatToInt64(value); ok {
+			return tw.Int64(i)
 		}
 		out := tw.tempBytes[0:0]
 		out = strconv.AppendFloat(out, value, 'g', -1, 64)
@@ -147,7 +197,14 @@ func (tw *tokenWriter) writeQuotedString(s string) error {
 			if aByte < utf8.RuneSelf { // single-byte character
 				i++
 			} else {
-				_, size := utf8.DecodeRuneInString(s[i:])
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if r == utf8.RuneError && size == 1 { // invalid UTF-8, replaced with U+FFFD as in json.Marshal
+					if i > start {
+						tw.buf.WriteString(s[start:i])
+					}
+					tw.buf.Write(escapedReplacementChar)
+					start = i + 1
+				}
 				i += size
 			}
 		}
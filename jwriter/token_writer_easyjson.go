@@ -42,6 +42,25 @@ func newStreamingTokenWriter(dest io.Writer, bufferSize int) tokenWriter {
 	return tw
 }
 
+func (tw *tokenWriter) newTemporary() tokenWriter {
+	pWriter := tw.pWriter
+	if pWriter == nil {
+		pWriter = &tw.inlineWriter
+	}
+	return tokenWriter{inlineWriter: ejwriter.Writer{Flags: pWriter.Flags, NoEscapeHTML: pWriter.NoEscapeHTML}}
+}
+
+func (tw *tokenWriter) stringEscaping() StringEscaping {
+	pWriter := tw.pWriter
+	if pWriter == nil {
+		pWriter = &tw.inlineWriter
+	}
+	if pWriter.NoEscapeHTML {
+		return EscapeLineTerminators
+	}
+	return EscapeLikeEncodingJSON
+}
+
 func (tw *tokenWriter) Bytes() []byte {
 	pWriter := tw.pWriter
 	if pWriter == nil {
@@ -51,6 +70,14 @@ func (tw *tokenWriter) Bytes() []byte {
 	return bytes
 }
 
+func (tw *tokenWriter) Size() int {
+	pWriter := tw.pWriter
+	if pWriter == nil {
+		pWriter = &tw.inlineWriter
+	}
+	return pWriter.Size()
+}
+
 func (tw *tokenWriter) Grow(n int) {
 	pWriter := tw.pWriter
 	if pWriter == nil {
@@ -94,10 +121,39 @@ func (tw *tokenWriter) Int(value int) error {
 	return tw.maybeFlush()
 }
 
+func (tw *tokenWriter) Int64(value int64) error {
+	pWriter := tw.pWriter
+	if pWriter == nil {
+		pWriter = &tw.inlineWriter
+	}
+	pWriter.Int64(value)
+	return tw.maybeFlush()
+}
+
+func (tw *tokenWriter) Uint64(value uint64) error {
+	pWriter := tw.pWriter
+	if pWriter == nil {
+		pWriter = &tw.inlineWriter
+	}
+	pWriter.Uint64(value)
+	return tw.maybeFlush()
+}
+
+func (tw *tokenWriter) Float32(value float32) error {
+	if i, ok := floatToInt64(float64(value)); ok {
+		return tw.Int64(i)
+	}
+	pWriter := tw.pWriter
+	if pWriter == nil {
+		pWriter = &tw.inlineWriter
+	}
+	pWriter.Float32(value)
+	return tw.maybeFlush()
+}
+
 func (tw *tokenWriter) Float64(value float64) error {
-	i := int(value)
-	if float64(i) == value {
-		return tw.Int(i)
+	if i, ok := floatToInt64(value); ok {
+		return tw.Int64(i)
 	}
 	pWriter := tw.pWriter
 	if pWriter == nil {
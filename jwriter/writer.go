@@ -19,9 +19,15 @@ import (
 // permanently enters a failed state and remembers that error; all subsequent method calls for
 // producing output will be ignored.
 type Writer struct {
-	tw    tokenWriter
-	err   error
-	state writerState
+	tw      tokenWriter
+	err     error
+	state   writerState
+	indent  *indentState
+	enc     *encodingOptions
+	sorting []sortedObject
+	names   *nameTracker
+	strict  bool
+	refs    references
 }
 
 // writerState keeps track of semantic state such as whether we're within an array. This has
@@ -30,11 +36,24 @@ type Writer struct {
 type writerState struct {
 	inArray       bool
 	arrayHasItems bool
+	depth         int
+
+	// hasValue is true if a value has been written at this level; needsValue is true if we are in
+	// an object and a property name has been written without a value. These are only used for
+	// checking the structure of the output (see SetStrict and Finish).
+	hasValue   bool
+	needsValue bool
 }
 
 // Bytes returns the full contents of the output buffer.
+//
+// In strict mode (see SetStrict), if an array or object is still open, the Writer enters a failed
+// state.
 func (w *Writer) Bytes() []byte {
-	return w.tw.Bytes()
+	if w.strict {
+		w.checkComplete()
+	}
+	return w.output().Bytes()
 }
 
 // Error returns the first error, if any, that occurred during output generation. If there have
@@ -55,8 +74,29 @@ func (w *Writer) AddError(err error) {
 
 // Flush writes any remaining in-memory output to the underlying io.Writer, if this is a streaming
 // writer created with NewStreamingWriter. It has no effect otherwise.
+//
+// In strict mode (see SetStrict), if an array or object is still open, the Writer enters a failed
+// state and Flush returns that error.
 func (w *Writer) Flush() error {
-	return w.tw.Flush()
+	if w.strict {
+		w.checkComplete()
+		if w.err != nil {
+			return w.err
+		}
+	}
+	return w.output().Flush()
+}
+
+// output returns the tokenWriter for the real output. This is not the same as w.tw if we are
+// currently buffering part of the output in a temporary tokenWriter.
+func (w *Writer) output() *tokenWriter {
+	if len(w.sorting) > 0 {
+		return &w.sorting[0].saved
+	}
+	if w.indent != nil && w.indent.pending {
+		return &w.indent.saved
+	}
+	return &w.tw
 }
 
 // Null writes a JSON null value to the output.
@@ -86,6 +126,10 @@ func (w *Writer) BoolOrNull(isDefined bool, value bool) {
 // Int writes a JSON numeric value to the output.
 func (w *Writer) Int(value int) {
 	if w.beforeValue() {
+		if w.isCanonical() {
+			w.writeCanonicalNumber(float64(value))
+			return
+		}
 		w.AddError(w.tw.Int(value))
 	}
 }
@@ -100,9 +144,47 @@ func (w *Writer) IntOrNull(isDefined bool, value int) {
 	}
 }
 
+// Int64 writes a JSON numeric value to the output.
+func (w *Writer) Int64(value int64) {
+	if w.beforeValue() {
+		if w.isCanonical() {
+			w.writeCanonicalNumber(float64(value))
+			return
+		}
+		w.AddError(w.tw.Int64(value))
+	}
+}
+
+// Uint64 writes a JSON numeric value to the output.
+func (w *Writer) Uint64(value uint64) {
+	if w.beforeValue() {
+		if w.isCanonical() {
+			w.writeCanonicalNumber(float64(value))
+			return
+		}
+		w.AddError(w.tw.Uint64(value))
+	}
+}
+
 // Float64 writes a JSON numeric value to the output.
+//
+// The format of the number can be changed with SetFloatFormat. A NaN or infinite value cannot be
+// represented as a JSON number; by default, it causes the Writer to enter a failed state with an
+// UnsupportedValueError. See SetNonFiniteNumberPolicy.
 func (w *Writer) Float64(value float64) {
+	if isNonFinite(value) {
+		w.writeNonFinite(value)
+		return
+	}
 	if w.beforeValue() {
+		if w.isCanonical() {
+			w.writeCanonicalNumber(value)
+			return
+		}
+		if w.hasFloatFormat() {
+			w.writeFormattedFloat(value, 64)
+			return
+		}
 		w.AddError(w.tw.Float64(value))
 	}
 }
@@ -117,9 +199,38 @@ func (w *Writer) Float64OrNull(isDefined bool, value float64) {
 	}
 }
 
+// Float32 writes a JSON numeric value to the output, using the fewest digits that represent the
+// same float32 value: for instance, float32(0.1) is written as 0.1, whereas Float64 would write
+// it as 0.10000000149011612. NaN and infinite values are treated the same as in Float64.
+func (w *Writer) Float32(value float32) {
+	if isNonFinite(float64(value)) {
+		w.writeNonFinite(float64(value))
+		return
+	}
+	if w.beforeValue() {
+		if w.isCanonical() {
+			w.writeCanonicalNumber(float64(value))
+			return
+		}
+		if w.hasFloatFormat() {
+			w.writeFormattedFloat(float64(value), 32)
+			return
+		}
+		w.AddError(w.tw.Float32(value))
+	}
+}
+
 // String writes a JSON string value to the output, adding quotes and performing any necessary escaping.
+// Invalid UTF-8 is handled as described in SetInvalidUTF8Policy.
 func (w *Writer) String(value string) {
+	if !w.checkUTF8(value, false) {
+		return
+	}
 	if w.beforeValue() {
+		if w.encodesStrings() {
+			w.writeEncodedString(value)
+			return
+		}
 		w.AddError(w.tw.String(value))
 	}
 }
@@ -134,12 +245,28 @@ func (w *Writer) StringOrNull(isDefined bool, value string) {
 	}
 }
 
-// Raw writes a pre-encoded JSON value to the output as-is. Its format is assumed to be correct; this
-// operation will not fail unless it is not permitted to write a value at this point.
+// Raw writes a pre-encoded JSON value to the output as-is. By default, its format is assumed to be
+// correct; this operation will not fail unless it is not permitted to write a value at this point.
+// To check the format, see SetRawValuePolicy.
+//
+// If SetIndent or SetIndentOptions has been used, an array or object written with Raw is parsed
+// and written again with the same indentation as the rest of the output, as json.MarshalIndent
+// does; in that case, if it is not valid JSON, the Writer enters a failed state.
 func (w *Writer) Raw(value json.RawMessage) {
 	if value == nil {
 		w.Null()
-	} else if w.beforeValue() {
+		return
+	}
+	if w.isCanonical() || ((w.indent != nil || (w.enc != nil && w.enc.sortKeys)) && !isScalarJSON(value)) {
+		w.writeDecodedRaw(value)
+		return
+	}
+	if w.enc != nil && w.enc.raw != RawValuesUnchecked && w.err == nil {
+		if value = w.checkRaw(value); value == nil {
+			return
+		}
+	}
+	if w.beforeValue() {
 		w.AddError(w.tw.Raw(value))
 	}
 }
@@ -147,13 +274,19 @@ func (w *Writer) Raw(value json.RawMessage) {
 // Array begins writing a JSON array to the output. It returns an ArrayState that provides the array
 // formatting; you must call ArrayState.End() when finished.
 func (w *Writer) Array() ArrayState {
+	if w.indent != nil && w.indent.pending {
+		w.expandPendingArray()
+	}
 	if w.beforeValue() {
 		if err := w.tw.Delimiter('['); err != nil {
 			w.err = err
 			return ArrayState{}
 		}
 		previousState := w.state
-		w.state = writerState{inArray: true}
+		w.state = writerState{inArray: true, depth: previousState.depth + 1}
+		if w.indent != nil && w.indent.options.MaxCompactArrayLength > 0 {
+			w.beginPendingArray()
+		}
 		return ArrayState{w: w, previousState: previousState}
 	}
 	return ArrayState{}
@@ -162,13 +295,23 @@ func (w *Writer) Array() ArrayState {
 // Object begins writing a JSON object to the output. It returns an ObjectState that provides the
 // object formatting; you must call ObjectState.End() when finished.
 func (w *Writer) Object() ObjectState {
+	if w.indent != nil && w.indent.pending {
+		w.expandPendingArray()
+	}
 	if w.beforeValue() {
 		if err := w.tw.Delimiter('{'); err != nil {
 			w.err = err
 			return ObjectState{}
 		}
 		previousState := w.state
-		w.state = writerState{inArray: false}
+		w.state = writerState{inArray: false, depth: previousState.depth + 1}
+		if w.names != nil {
+			w.names.beginObject()
+		}
+		if w.enc != nil && w.enc.sortKeys {
+			w.beginSortedObject()
+			return ObjectState{w: w, sorted: true, previousState: previousState}
+		}
 		return ObjectState{w: w, previousState: previousState}
 	}
 	return ObjectState{}
@@ -178,15 +321,26 @@ func (w *Writer) beforeValue() bool {
 	if w.err != nil {
 		return false
 	}
-	if w.state.inArray {
-		if w.state.arrayHasItems {
-			if err := w.tw.Delimiter(','); err != nil {
-				w.AddError(err)
-				return false
-			}
-		} else {
-			w.state.arrayHasItems = true
+	if w.strict && !w.checkValueAllowed() {
+		return false
+	}
+	if !w.state.inArray {
+		w.state.hasValue, w.state.needsValue = true, false
+		return true
+	}
+	if w.indent != nil && w.indent.pending && w.beforePendingElement() {
+		return true
+	}
+	if w.state.arrayHasItems {
+		if err := w.tw.Delimiter(','); err != nil {
+			w.AddError(err)
+			return false
 		}
+	} else {
+		w.state.arrayHasItems = true
+	}
+	if w.indent != nil {
+		w.writeNewline(w.state.depth)
 	}
 	return true
 }
@@ -1,6 +1,9 @@
 package jwriter
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"math/big"
+)
 
 // ArrayState is a decorator that manages the state of a JSON array that is in the process of being
 // written.
@@ -34,6 +37,20 @@ func (arr *ArrayState) Int(value int) {
 	}
 }
 
+// Int64 is equivalent to writer.Int64(value).
+func (arr *ArrayState) Int64(value int64) {
+	if arr.w != nil {
+		arr.w.Int64(value)
+	}
+}
+
+// Uint64 is equivalent to writer.Uint64(value).
+func (arr *ArrayState) Uint64(value uint64) {
+	if arr.w != nil {
+		arr.w.Uint64(value)
+	}
+}
+
 // Float64 is equivalent to writer.Float64(value).
 func (arr *ArrayState) Float64(value float64) {
 	if arr.w != nil {
@@ -41,6 +58,34 @@ func (arr *ArrayState) Float64(value float64) {
 	}
 }
 
+// Float32 is equivalent to writer.Float32(value).
+func (arr *ArrayState) Float32(value float32) {
+	if arr.w != nil {
+		arr.w.Float32(value)
+	}
+}
+
+// Number is equivalent to writer.Number(value).
+func (arr *ArrayState) Number(value json.Number) {
+	if arr.w != nil {
+		arr.w.Number(value)
+	}
+}
+
+// BigInt is equivalent to writer.BigInt(value).
+func (arr *ArrayState) BigInt(value *big.Int) {
+	if arr.w != nil {
+		arr.w.BigInt(value)
+	}
+}
+
+// BigFloat is equivalent to writer.BigFloat(value).
+func (arr *ArrayState) BigFloat(value *big.Float) {
+	if arr.w != nil {
+		arr.w.BigFloat(value)
+	}
+}
+
 // String is equivalent to writer.String(value).
 func (arr *ArrayState) String(value string) {
 	if arr.w != nil {
@@ -76,6 +121,16 @@ func (arr *ArrayState) End() {
 	if arr.w == nil || arr.w.err != nil {
 		return
 	}
+	if arr.w.strict && !arr.w.checkInnermost(arr.previousState.depth, "ArrayState.End") {
+		return
+	}
+	if arr.w.indent != nil {
+		if arr.w.indent.pending {
+			arr.w.writeCompactArray()
+		} else if arr.w.state.arrayHasItems {
+			arr.w.writeNewline(arr.previousState.depth)
+		}
+	}
 	arr.w.AddError(arr.w.tw.Delimiter(']'))
 	arr.w.state = arr.previousState
 	arr.w = nil
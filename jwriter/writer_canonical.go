@@ -0,0 +1,120 @@
+package jwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SetCanonical causes the Writer to produce the JSON Canonicalization Scheme format defined by
+// RFC 8785, so that equivalent data always produces byte-for-byte identical output, as is needed
+// for computing hashes or signatures of JSON data. Specifically:
+//
+// - The members of each object are written in order of their property names, compared as
+// sequences of UTF-16 code units. This means that the members of each object are buffered in
+// memory until the object ends.
+//
+// - Numbers are formatted as in ECMAScript: integers up to 1e21 without an exponent or decimal
+// point, and other values with the fewest digits that represent the same value, for instance
+// 0.000001, 1e-7, and 1.5e+21. Like all numbers in RFC 8785, values written with Int are treated
+// as 64-bit floating-point values, so large integers may lose precision.
+//
+// - Strings escape only the characters that must be escaped, overriding SetStringEscaping.
+//
+// - Values written with Raw are parsed and re-encoded in the same way; if the value is not valid
+// JSON, the Writer enters a failed state.
+//
+// There is no whitespace in the output; SetCanonical turns off indentation if it was enabled. RFC
+// 8785 does not allow NaN or infinite numbers, so unless you have changed the policy with
+// SetNonFiniteNumberPolicy, trying to write one causes an UnsupportedValueError.
+//
+// This should be called before anything is written.
+func (w *Writer) SetCanonical() {
+	enc := w.encoding()
+	enc.canonical = true
+	enc.encodeStrings = true
+	enc.escaping = literalReplacementChar
+	enc.sortKeys = true
+	w.indent = nil
+}
+
+// Canonicalize parses a JSON value and re-encodes it in the RFC 8785 canonical format, as described
+// by Writer.SetCanonical. It returns an error if the data is not a single valid JSON value.
+func Canonicalize(data []byte) ([]byte, error) {
+	w := NewWriter()
+	w.SetCanonical()
+	w.writeDecodedRaw(data)
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+func (w *Writer) writeCanonicalNumber(value float64) {
+	w.enc.scratch = appendESNumber(w.enc.scratch[:0], value, 64)
+	w.AddError(w.tw.Raw(w.enc.scratch))
+}
+
+// writeDecodedRaw parses a pre-encoded JSON value and writes it token by token, so that it is
+// formatted in the same way as all other output. This is needed in canonical mode, and for arrays
+// and objects in sorted-keys mode.
+func (w *Writer) writeDecodedRaw(value json.RawMessage) {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	w.writeDecodedValue(dec)
+	if w.err == nil {
+		if _, err := dec.Token(); err != io.EOF {
+			w.AddError(fmt.Errorf("unexpected data after end of raw JSON value at position %d", dec.InputOffset()))
+		}
+	}
+}
+
+// writeDecodedValue reads the next JSON value from a json.Decoder, which must have UseNumber
+// enabled, and writes it token by token. We use encoding/json's tokenizer here, rather than
+// jreader, so that jwriter does not depend on jreader.
+func (w *Writer) writeDecodedValue(dec *json.Decoder) {
+	token, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		w.AddError(err)
+		return
+	}
+	switch value := token.(type) {
+	case nil:
+		w.Null()
+	case bool:
+		w.Bool(value)
+	case json.Number:
+		w.Number(value)
+	case string:
+		w.String(value)
+	case json.Delim:
+		if value == '[' {
+			arr := w.Array()
+			for dec.More() && w.err == nil {
+				w.writeDecodedValue(dec)
+			}
+			arr.End()
+		} else {
+			obj := w.Object()
+			for dec.More() && w.err == nil {
+				name, err := dec.Token()
+				if err != nil {
+					w.AddError(err)
+					return
+				}
+				obj.Name(name.(string)).writeDecodedValue(dec)
+			}
+			obj.End()
+		}
+		if _, err := dec.Token(); err != nil && w.err == nil { // the closing delimiter
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			w.AddError(err)
+		}
+	}
+}
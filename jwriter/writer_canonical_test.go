@@ -0,0 +1,265 @@
+package jwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalNumbers(t *testing.T) {
+	// These are the examples from RFC 8785, Appendix B.
+	for _, p := range []struct {
+		bits     uint64
+		expected string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	} {
+		t.Run(p.expected, func(t *testing.T) {
+			w := NewWriter()
+			w.SetCanonical()
+			w.Float64(math.Float64frombits(p.bits))
+			require.NoError(t, w.Error())
+			assert.Equal(t, p.expected, string(w.Bytes()))
+		})
+	}
+}
+
+func TestCanonicalInt(t *testing.T) {
+	for _, p := range []struct {
+		value    int
+		expected string
+	}{
+		{0, "0"},
+		{-3, "-3"},
+		{1000000, "1000000"},
+		{1 << 53, "9007199254740992"},
+		{1<<53 + 1, "9007199254740992"}, // RFC 8785 treats all numbers as 64-bit floating-point
+	} {
+		t.Run(p.expected, func(t *testing.T) {
+			w := NewWriter()
+			w.SetCanonical()
+			w.Int(p.value)
+			assert.Equal(t, p.expected, string(w.Bytes()))
+		})
+	}
+}
+
+func TestCanonicalNumberLiterals(t *testing.T) {
+	for _, p := range []struct {
+		value    json.Number
+		expected string
+	}{
+		{"1.50", "1.5"},
+		{"2E3", "2000"},
+		{"-0.0", "0"},
+		{"9223372036854775808", "9223372036854776000"}, // 2^63, just outside of int64
+		{"123456789012345678901", "123456789012345680000"},
+		{"-18446744073709551617", "-18446744073709552000"},
+	} {
+		t.Run(string(p.value), func(t *testing.T) {
+			w := NewWriter()
+			w.SetCanonical()
+			w.Number(p.value)
+			require.NoError(t, w.Error())
+			assert.Equal(t, p.expected, string(w.Bytes()))
+
+			w = NewWriter()
+			w.SetCanonical()
+			w.Raw(json.RawMessage(p.value))
+			require.NoError(t, w.Error())
+			assert.Equal(t, p.expected, string(w.Bytes()))
+		})
+	}
+}
+
+func TestCanonicalNonFiniteNumberIsError(t *testing.T) {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		w := NewWriter()
+		w.SetCanonical()
+		arr := w.Array()
+		arr.Float64(value)
+		arr.End()
+		require.Error(t, w.Error())
+		assert.IsType(t, UnsupportedValueError{}, w.Error())
+	}
+}
+
+func TestCanonicalStrings(t *testing.T) {
+	for _, p := range []struct {
+		value, expected string
+	}{
+		{"", `""`},
+		{"abc", `"abc"`},
+		{"\"\\/", `"\"\\/"`},
+		{"\b\t\n\f\r", `"\b\t\n\f\r"`},
+		{"\x00\x0f\x1f\x7f", `"\u0000\u000f\u001f` + "\x7f\""},
+		{"<>&\u2028\u2029", "\"<>&\u2028\u2029\""},
+		{"€😀", `"€😀"`},
+		{"a\xffb", "\"a�b\""},
+	} {
+		t.Run(p.expected, func(t *testing.T) {
+			w := NewWriter()
+			w.SetCanonical()
+			w.String(p.value)
+			assert.Equal(t, p.expected, string(w.Bytes()))
+		})
+	}
+}
+
+func TestCanonicalSortsProperties(t *testing.T) {
+	w := NewWriter()
+	w.SetCanonical()
+	obj := w.Object()
+	obj.Name("b").Int(1)
+	inner := obj.Name("a").Object()
+	inner.Name("z").Bool(true)
+	inner.Name("y").Null()
+	arr := inner.Name("x").Array()
+	arr.Int(2)
+	emptyObj := arr.Object()
+	emptyObj.End()
+	arr.End()
+	inner.End()
+	obj.Name("c").String("x")
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":{"x":[2,{}],"y":null,"z":true},"b":1,"c":"x"}`, string(w.Bytes()))
+}
+
+func TestCanonicalSortsByUTF16CodeUnits(t *testing.T) {
+	// This is the example from RFC 8785, section 3.2.3. U+1F600 is represented in UTF-16 by the
+	// surrogate pair D83D DE00, so it sorts before U+FB33 even though its code point is higher.
+	input := `{"€":"Euro Sign","\r":"Carriage Return","דּ":"Hebrew Letter Dalet With Dagesh",` +
+		`"1":"One","😀":"Emoji: Grinning Face","\u0080":"Control",` +
+		`"ö":"Latin Small Letter O With Diaeresis"}`
+	w := NewWriter()
+	w.SetCanonical()
+	w.Raw(json.RawMessage(input))
+	require.NoError(t, w.Error())
+
+	r := jreader.NewReader(w.Bytes())
+	var names []string
+	for obj := r.Object(); obj.Next(); {
+		names = append(names, string(obj.Name()))
+		require.NoError(t, r.SkipValue())
+	}
+	require.NoError(t, r.Error())
+	assert.Equal(t, []string{"\r", "1", "\u0080", "ö", "€", "\U0001f600", "דּ"}, names)
+}
+
+func TestLessUTF16(t *testing.T) {
+	assert.True(t, lessUTF16("", "a"))
+	assert.False(t, lessUTF16("a", ""))
+	assert.False(t, lessUTF16("a", "a"))
+	assert.True(t, lessUTF16("a", "ab"))
+	assert.True(t, lessUTF16("ab", "b"))
+	assert.True(t, lessUTF16("\U0001f600", "דּ"))
+	assert.True(t, lessUTF16("\U0001f600", "\U0001f601"))
+	assert.True(t, lessUTF16("\U00010000", "\U0001f600"))
+}
+
+func TestCanonicalize(t *testing.T) {
+	// This is the example from RFC 8785, section 3.2.2.
+	input := `{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`
+	expected := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],` +
+		`"string":"€$\u000f\nA'B\"\\\\\"/"}`
+	out, err := Canonicalize([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+}
+
+func TestCanonicalizeUsesExactNumberLiterals(t *testing.T) {
+	// These integers cannot be represented exactly by int64 or uint64, so they must be parsed from
+	// their original text rather than from a value that was already converted.
+	out, err := Canonicalize([]byte(`[123456789012345678901, -9223372036854775809, 18446744073709551616]`))
+	require.NoError(t, err)
+	assert.Equal(t, `[123456789012345680000,-9223372036854776000,18446744073709552000]`, string(out))
+}
+
+func TestCanonicalizeErrors(t *testing.T) {
+	for _, input := range []string{``, `{"a":`, `"abc`, `{} {}`, `nul`} {
+		t.Run(input, func(t *testing.T) {
+			_, err := Canonicalize([]byte(input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCanonicalRaw(t *testing.T) {
+	w := NewWriter()
+	w.SetCanonical()
+	obj := w.Object()
+	obj.Name("b").Raw(json.RawMessage(` { "y": 1.50, "x": [ 1E2 ] } `))
+	obj.Name("a").Raw(nil)
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":null,"b":{"x":[100],"y":1.5}}`, string(w.Bytes()))
+}
+
+func TestCanonicalRawError(t *testing.T) {
+	for _, value := range []string{``, `{"a":`, `{"a" 1}`, `[1 x]`, `[1,`, `"abc`, `1 2`, `[] {}`} {
+		t.Run(value, func(t *testing.T) {
+			w := NewWriter()
+			w.SetCanonical()
+			w.Raw(json.RawMessage(value))
+			assert.Error(t, w.Error())
+		})
+	}
+}
+
+func TestCanonicalTurnsOffIndentation(t *testing.T) {
+	w := NewWriter()
+	w.SetIndent("", "  ")
+	w.SetCanonical()
+	w.Value(map[string]interface{}{"b": []interface{}{1.0, "x"}, "a": true})
+	assert.Equal(t, `{"a":true,"b":[1,"x"]}`, string(w.Bytes()))
+}
+
+func TestCanonicalWithStreamingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewStreamingWriter(&buf, 1000)
+	w.SetCanonical()
+	arr := w.Array()
+	obj := arr.Object()
+	obj.Name("b").Int(2)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, `[{`, buf.String())
+	obj.Name("a").Int(1)
+	obj.End()
+	arr.End()
+	require.NoError(t, w.Flush())
+	assert.Equal(t, `[{"a":1,"b":2}]`, buf.String())
+}
@@ -0,0 +1,87 @@
+package jwriter
+
+import "sort"
+
+// WriteSlice writes a JSON array, calling writeElement to write each element. A nil slice is
+// written as a JSON null; an empty non-nil slice is written as an empty array.
+//
+// The writeElement function should write exactly one JSON value using the Writer's methods. It can
+// be a method expression such as (*Writer).String, a function like WriteWritable, or a closure.
+//
+//	jwriter.WriteSlice(w, names, (*jwriter.Writer).String)
+//	jwriter.WriteSlice(w, items, jwriter.WriteWritable[MyItem])
+func WriteSlice[T any](w *Writer, values []T, writeElement func(*Writer, T)) {
+	if values == nil {
+		w.Null()
+		return
+	}
+	arr := w.Array()
+	for _, value := range values {
+		if w.err != nil {
+			break
+		}
+		writeElement(w, value)
+	}
+	arr.End()
+}
+
+// WriteMap writes a JSON object, calling writeValue to write each property value. A nil map is
+// written as a JSON null; an empty non-nil map is written as an empty object.
+//
+// Properties are written in ascending order of their names, so that the output is deterministic
+// regardless of Go's randomized map iteration order. This requires allocating a temporary slice of
+// the map keys.
+//
+// The writeValue function should write exactly one JSON value using the Writer's methods. It can be
+// a method expression such as (*Writer).Int, a function like WriteWritable, or a closure.
+func WriteMap[K ~string, V any](w *Writer, values map[K]V, writeValue func(*Writer, V)) {
+	if values == nil {
+		w.Null()
+		return
+	}
+	keys := make([]K, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sortStrings(keys)
+	obj := w.Object()
+	for _, key := range keys {
+		if w.err != nil {
+			break
+		}
+		writeValue(obj.Name(string(key)), values[key])
+	}
+	obj.End()
+}
+
+// WriteWritable writes a value of any type that implements Writable, by calling its
+// WriteToJSONWriter method. It is meant to be passed as a parameter to WriteSlice or WriteMap:
+//
+//	jwriter.WriteSlice(w, items, jwriter.WriteWritable[MyItem])
+//
+// Unlike converting each value to the Writable interface, this does not cause the value to be
+// allocated on the heap.
+func WriteWritable[T Writable](w *Writer, value T) {
+	value.WriteToJSONWriter(w)
+}
+
+// sortStrings sorts a slice of string-like values in ascending byte order. Small slices, which are
+// the common case for JSON objects, are sorted in place by insertion sort to avoid the allocation
+// that would be caused by converting the slice to sort.Interface.
+func sortStrings[K ~string](keys []K) {
+	if len(keys) > 12 {
+		sort.Sort(stringSorter[K](keys))
+		return
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j] < keys[j-1]; j-- {
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+}
+
+type stringSorter[K ~string] []K
+
+func (s stringSorter[K]) Len() int           { return len(s) }
+func (s stringSorter[K]) Less(i, j int) bool { return s[i] < s[j] }
+func (s stringSorter[K]) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,113 @@
+package jwriter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writableItem struct {
+	key   string
+	count int
+}
+
+func (item writableItem) WriteToJSONWriter(w *Writer) {
+	obj := w.Object()
+	obj.Name("key").String(item.key)
+	obj.Name("count").Int(item.count)
+	obj.End()
+}
+
+func TestWriteSlice(t *testing.T) {
+	t.Run("scalars", func(t *testing.T) {
+		w := NewWriter()
+		WriteSlice(&w, []string{"a", "b"}, (*Writer).String)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `["a","b"]`, string(w.Bytes()))
+	})
+
+	t.Run("writables", func(t *testing.T) {
+		w := NewWriter()
+		WriteSlice(&w, []writableItem{{"a", 1}, {"b", 2}}, WriteWritable[writableItem])
+		require.NoError(t, w.Error())
+		assert.Equal(t, `[{"key":"a","count":1},{"key":"b","count":2}]`, string(w.Bytes()))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		w := NewWriter()
+		WriteSlice(&w, []int{}, (*Writer).Int)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `[]`, string(w.Bytes()))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		w := NewWriter()
+		WriteSlice(&w, []int(nil), (*Writer).Int)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `null`, string(w.Bytes()))
+	})
+
+	t.Run("stops after error", func(t *testing.T) {
+		err := errors.New("sorry")
+		w := NewWriter()
+		n := 0
+		WriteSlice(&w, []int{1, 2, 3}, func(w *Writer, value int) {
+			n++
+			w.Int(value)
+			w.AddError(err)
+		})
+		assert.Equal(t, err, w.Error())
+		assert.Equal(t, 1, n)
+	})
+}
+
+func TestWriteMap(t *testing.T) {
+	t.Run("sorted keys", func(t *testing.T) {
+		w := NewWriter()
+		WriteMap(&w, map[string]int{"c": 3, "a": 1, "b": 2}, (*Writer).Int)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"a":1,"b":2,"c":3}`, string(w.Bytes()))
+	})
+
+	t.Run("many sorted keys", func(t *testing.T) {
+		m := make(map[string]int)
+		expected := `{`
+		for i := 0; i < 26; i++ {
+			name := string(rune('a' + i))
+			m[name] = i
+			if i > 0 {
+				expected += ","
+			}
+			expected += `"` + name + `":` + string(rune('0'+i%10))
+		}
+		expected += `}`
+		w := NewWriter()
+		WriteMap(&w, m, func(w *Writer, value int) { w.Int(value % 10) })
+		require.NoError(t, w.Error())
+		assert.Equal(t, expected, string(w.Bytes()))
+	})
+
+	t.Run("string-like key type and writables", func(t *testing.T) {
+		type itemKey string
+		w := NewWriter()
+		WriteMap(&w, map[itemKey]writableItem{"y": {"b", 2}, "x": {"a", 1}}, WriteWritable[writableItem])
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"x":{"key":"a","count":1},"y":{"key":"b","count":2}}`, string(w.Bytes()))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		w := NewWriter()
+		WriteMap(&w, map[string]int{}, (*Writer).Int)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{}`, string(w.Bytes()))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		w := NewWriter()
+		WriteMap(&w, map[string]int(nil), (*Writer).Int)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `null`, string(w.Bytes()))
+	})
+}
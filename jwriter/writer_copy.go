@@ -0,0 +1,98 @@
+package jwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ValueSource is the input that CopyFrom reads from. It is implemented by *jreader.Reader; jwriter
+// does not refer to jreader directly, so that either package can be used without the other.
+type ValueSource interface {
+	// RawValue consumes the next JSON value, including all nested values within an array or
+	// object, and returns its text. It returns nil if there is a parsing error.
+	RawValue() json.RawMessage
+	// Error returns the first error that the source encountered, if any.
+	Error() error
+	// AddError puts the source into a failed state with the specified error, unless it has
+	// already failed.
+	AddError(err error)
+}
+
+// CopyFrom reads the next JSON value of any type from the source, including all nested values
+// within an array or object, and writes it to the Writer. This can be used to embed part of an
+// input document in the output without decoding it into Go types. The source is normally a
+// *jreader.Reader.
+//
+// Numbers are written exactly as they appeared in the input, so no precision is lost (unless the
+// Writer is in canonical mode, which reformats all numbers). Strings, including property names,
+// have the same content, although characters may be escaped differently in the output. Whitespace
+// is not preserved; the output is formatted by the Writer.
+//
+// If there is a parsing error, the source enters a failed state, which you can detect with Error(),
+// and the same error is added to the Writer so that it stops producing output. The value is
+// consumed from the source even if the Writer was already in a failed state.
+func (w *Writer) CopyFrom(src ValueSource) {
+	value := src.RawValue()
+	if err := src.Error(); err != nil {
+		w.AddError(err)
+		return
+	}
+	w.writeDecodedRaw(value)
+}
+
+// CopyFrom is equivalent to writer.CopyFrom(src).
+func (arr *ArrayState) CopyFrom(src ValueSource) {
+	if arr.w != nil {
+		arr.w.CopyFrom(src)
+	} else {
+		_ = src.RawValue()
+	}
+}
+
+// CopyFrom reads the next JSON value from the source, which must be an object, and writes each of
+// its properties to this object in the same way as Writer.CopyFrom. If the value is not an object,
+// the source and the Writer enter a failed state with the same error.
+func (obj *ObjectState) CopyFrom(src ValueSource) {
+	value := src.RawValue()
+	if err := src.Error(); err != nil {
+		if obj.w != nil {
+			obj.w.AddError(err)
+		}
+		return
+	}
+	if obj.w == nil {
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	if token, _ := dec.Token(); token != json.Delim('{') {
+		err := fmt.Errorf("expected JSON object, found %s", describeToken(token))
+		src.AddError(err)
+		obj.w.AddError(err)
+		return
+	}
+	for dec.More() && obj.w.err == nil {
+		name, err := dec.Token()
+		if err != nil { // COVERAGE: the source has already validated the value
+			obj.w.AddError(err)
+			return
+		}
+		obj.Name(name.(string)).writeDecodedValue(dec)
+	}
+}
+
+func describeToken(token json.Token) string {
+	switch token.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	default:
+		return "array"
+	}
+}
@@ -0,0 +1,90 @@
+package jwriter
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ ValueSource = &jreader.Reader{}
+
+func TestCopyFrom(t *testing.T) {
+	input := `{ "a": [1.50, -2e+3, 12345678901234567890], "b\u00e9": "x\/y\n", "c": {}, "d": null } 9`
+	r := jreader.NewReader([]byte(input))
+	w := NewWriter()
+	w.CopyFrom(&r)
+	require.NoError(t, w.Error())
+	require.NoError(t, r.Error())
+	assert.Equal(t, `{"a":[1.50,-2e+3,12345678901234567890],"bé":"x/y\n","c":{},"d":null}`, string(w.Bytes()))
+	assert.Equal(t, 9, r.Int()) // only one value was consumed
+}
+
+func TestCopyFromInCanonicalMode(t *testing.T) {
+	r := jreader.NewReader([]byte(`{"b":1.50,"a":[2e+3, 123456789012345678901]}`))
+	w := NewWriter()
+	w.SetCanonical()
+	w.CopyFrom(&r)
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":[2000,123456789012345680000],"b":1.5}`, string(w.Bytes()))
+}
+
+func TestArrayStateCopyFrom(t *testing.T) {
+	r := jreader.NewReader([]byte(`[true, "x"]`))
+	w := NewWriter()
+	arr := w.Array()
+	arr.Int(0)
+	for source := r.Array(); source.Next(); {
+		arr.CopyFrom(&r)
+	}
+	arr.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `[0,true,"x"]`, string(w.Bytes()))
+}
+
+func TestObjectStateCopyFrom(t *testing.T) {
+	r := jreader.NewReader([]byte(`{"b": [2], "c": {"d": 3}}`))
+	w := NewWriter()
+	obj := w.Object()
+	obj.Name("a").Int(1)
+	obj.CopyFrom(&r)
+	obj.Name("e").Int(4)
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":1,"b":[2],"c":{"d":3},"e":4}`, string(w.Bytes()))
+}
+
+func TestObjectStateCopyFromNonObject(t *testing.T) {
+	r := jreader.NewReader([]byte(`[1]`))
+	w := NewWriter()
+	obj := w.Object()
+	obj.CopyFrom(&r)
+	obj.End()
+	require.Error(t, r.Error())
+	assert.Equal(t, r.Error(), w.Error())
+	assert.EqualError(t, w.Error(), "expected JSON object, found array")
+}
+
+func TestCopyFromPropagatesReaderError(t *testing.T) {
+	r := jreader.NewReader([]byte(`{"a":[1,x]}`))
+	w := NewWriter()
+	w.CopyFrom(&r)
+	require.Error(t, r.Error())
+	assert.Equal(t, r.Error(), w.Error())
+}
+
+func TestCopyFromConsumesValueWithoutWriter(t *testing.T) {
+	r := jreader.NewReader([]byte(`[[1, {"a": 2}], {"b": 3}, 4]`))
+	var arr ArrayState
+	var obj ObjectState
+	source := r.Array()
+	require.True(t, source.Next())
+	arr.CopyFrom(&r)
+	require.True(t, source.Next())
+	obj.CopyFrom(&r)
+	require.True(t, source.Next())
+	assert.Equal(t, 4, r.Int())
+	require.NoError(t, r.Error())
+}
@@ -0,0 +1,96 @@
+package jwriter
+
+import "fmt"
+
+// DuplicateNamePolicy specifies what a Writer does if the same property name is written more than
+// once in an object. See Writer.SetDuplicateNamePolicy.
+type DuplicateNamePolicy int
+
+const (
+	// DuplicateNamesAllowed means that property names are not checked, so an object can contain
+	// the same name more than once. This is the default. The JSON specification does not forbid
+	// this, but consumers of the JSON data may handle it differently: some use the first value, some
+	// use the last, and some report an error.
+	DuplicateNamesAllowed DuplicateNamePolicy = iota
+
+	// DuplicateNamesError means that the Writer enters a failed state with a DuplicateNameError.
+	DuplicateNamesError
+
+	// DuplicateNamesDropLater means that the first value for each name is kept, and any later
+	// property with the same name is omitted from the output.
+	DuplicateNamesDropLater
+)
+
+// DuplicateNameError is returned if the same property name is written more than once in an
+// object, and the Writer's policy is DuplicateNamesError.
+type DuplicateNameError struct {
+	// Name is the property name.
+	Name string
+}
+
+// Error returns a description of the error.
+func (e DuplicateNameError) Error() string {
+	return fmt.Sprintf("duplicate property name %q", e.Name)
+}
+
+// nameTracker holds the property names that have been written so far in each object that is in
+// progress. It is only allocated if duplicate names are being checked. The sets for each level of
+// nesting are reused for later objects at the same level.
+type nameTracker struct {
+	policy DuplicateNamePolicy
+	levels []map[string]struct{}
+	depth  int
+}
+
+// SetDuplicateNamePolicy specifies what the Writer should do if the same property name is written
+// more than once in an object. The default is DuplicateNamesAllowed.
+//
+// With DuplicateNamesDropLater, ObjectState.Name returns a stub Writer, like ObjectState.Maybe
+// does when shouldWrite is false, for a name that was already written; so the property value must
+// be written with method calls on the return value of Name, rather than on some other reference to
+// the Writer, in order to be omitted.
+//
+// Checking for duplicates requires keeping a set of the names in each object that is in progress.
+//
+// This should be called before anything is written.
+func (w *Writer) SetDuplicateNamePolicy(policy DuplicateNamePolicy) {
+	if policy == DuplicateNamesAllowed {
+		w.names = nil
+		return
+	}
+	w.names = &nameTracker{policy: policy}
+}
+
+func (t *nameTracker) beginObject() {
+	if t.depth < len(t.levels) {
+		for name := range t.levels[t.depth] {
+			delete(t.levels[t.depth], name)
+		}
+	} else {
+		t.levels = append(t.levels, make(map[string]struct{}))
+	}
+	t.depth++
+}
+
+func (t *nameTracker) endObject() {
+	t.depth--
+}
+
+// checkDuplicateName returns true if the property can be written. If the name has already been
+// written in the current object, it either sets the error state or returns false, depending on the
+// policy.
+func (w *Writer) checkDuplicateName(name string) bool {
+	t := w.names
+	if t.depth == 0 {
+		return true // COVERAGE: can't happen unless an ObjectState is used after its object has ended
+	}
+	names := t.levels[t.depth-1]
+	if _, found := names[name]; !found {
+		names[name] = struct{}{}
+		return true
+	}
+	if t.policy == DuplicateNamesError {
+		w.AddError(DuplicateNameError{Name: name})
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package jwriter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeObjectWithDuplicates(w *Writer) {
+	obj := w.Object()
+	obj.Name("a").Int(1)
+	nested := obj.Name("b").Object()
+	nested.Name("a").Int(2) // same name at a different level is not a duplicate
+	nested.Name("c").Int(3)
+	nested.End()
+	arr := obj.Name("a").Array()
+	arr.Int(4)
+	arr.End()
+	obj.Name("c").Int(5)
+	obj.End()
+}
+
+func TestDuplicateNamesAllowedByDefault(t *testing.T) {
+	w := NewWriter()
+	writeObjectWithDuplicates(&w)
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":1,"b":{"a":2,"c":3},"a":[4],"c":5}`, string(w.Bytes()))
+}
+
+func TestDuplicateNamesError(t *testing.T) {
+	w := NewWriter()
+	w.SetDuplicateNamePolicy(DuplicateNamesError)
+	writeObjectWithDuplicates(&w)
+	assert.Equal(t, DuplicateNameError{Name: "a"}, w.Error())
+}
+
+func TestDuplicateNamesDropLater(t *testing.T) {
+	w := NewWriter()
+	w.SetDuplicateNamePolicy(DuplicateNamesDropLater)
+	writeObjectWithDuplicates(&w)
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":1,"b":{"a":2,"c":3},"c":5}`, string(w.Bytes()))
+}
+
+func TestDuplicateNamesDropLaterWithSortKeys(t *testing.T) {
+	w := NewWriter()
+	w.SetSortKeys()
+	w.SetDuplicateNamePolicy(DuplicateNamesDropLater)
+	writeObjectWithDuplicates(&w)
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":1,"b":{"a":2,"c":3},"c":5}`, string(w.Bytes()))
+}
+
+func TestDuplicateNamesAreCheckedSeparatelyForEachObject(t *testing.T) {
+	w := NewWriter()
+	w.SetDuplicateNamePolicy(DuplicateNamesError)
+	arr := w.Array()
+	for i := 0; i < 2; i++ {
+		obj := arr.Object()
+		obj.Name("a").Int(i)
+		obj.End()
+	}
+	arr.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `[{"a":0},{"a":1}]`, string(w.Bytes()))
+}
+
+func TestDuplicateNameErrorMessage(t *testing.T) {
+	assert.Equal(t, `duplicate property name "a"`, DuplicateNameError{Name: "a"}.Error())
+}
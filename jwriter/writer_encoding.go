@@ -0,0 +1,107 @@
+package jwriter
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// encodingOptions holds settings that change how the Writer encodes individual values. It is only
+// allocated if one of those settings has been changed, so it adds no overhead to the default
+// behavior.
+type encodingOptions struct {
+	// canonical is true if the Writer is producing RFC 8785 output; see SetCanonical.
+	canonical bool
+
+	// If encodeStrings is true, strings are escaped by the Writer according to the escaping flags,
+	// rather than by the tokenWriter. This is always true in canonical mode.
+	encodeStrings bool
+	escaping      StringEscaping
+
+	// nonFinite is the policy for NaN and infinite numbers; see SetNonFiniteNumberPolicy.
+	nonFinite NonFiniteNumberPolicy
+
+	// rejectInvalidUTF8 is true if the policy is InvalidUTF8Fail; see SetInvalidUTF8Policy.
+	rejectInvalidUTF8 bool
+
+	// floatFormat is the format for Float64 and Float32; see SetFloatFormat.
+	floatFormat FloatFormat
+
+	// raw is the policy for values written with Raw; see SetRawValuePolicy.
+	raw RawValuePolicy
+
+	// sortKeys is true if the members of each object are buffered so that they can be written in
+	// sorted order; see SetSortKeys.
+	sortKeys bool
+
+	// scratch is reused for formatting values that the tokenWriter does not format for us.
+	scratch []byte
+}
+
+func (w *Writer) encoding() *encodingOptions {
+	if w.enc == nil {
+		w.enc = &encodingOptions{}
+	}
+	return w.enc
+}
+
+func (w *Writer) isCanonical() bool {
+	return w.enc != nil && w.enc.canonical
+}
+
+// appendESNumber appends a finite number in the format produced by the ECMAScript Number toString
+// algorithm (ECMA-262, section 6.1.6.1.20), which RFC 8785 requires: the shortest sequence of
+// digits that converts back to the same value, written without an exponent if the decimal point
+// falls within 21 digits of the start and 6 digits before it, and without a trailing ".0".
+//
+// If bits is 32, the digits are the shortest that represent the same float32 value.
+func appendESNumber(buf []byte, value float64, bits int) []byte {
+	if value == 0 {
+		return append(buf, '0') // this also covers negative zero
+	}
+	if value < 0 {
+		buf = append(buf, '-')
+		value = -value
+	}
+	var scratch [32]byte
+	formatted := strconv.AppendFloat(scratch[:0], value, 'e', -1, bits)
+	e := bytes.IndexByte(formatted, 'e')
+	exponent, _ := strconv.Atoi(string(formatted[e+1:]))
+	var digitsBuf [24]byte
+	digits := append(digitsBuf[:0], formatted[0])
+	if e > 2 {
+		digits = append(digits, formatted[2:e]...) // skip the decimal point
+	}
+
+	// In the terms of the ECMAScript spec, the value is 0.digits times 10 to the power of n, and k
+	// is the number of digits.
+	k, n := len(digits), exponent+1
+	switch {
+	case k <= n && n <= 21:
+		buf = append(buf, digits...)
+		for i := k; i < n; i++ {
+			buf = append(buf, '0')
+		}
+	case 0 < n && n <= 21:
+		buf = append(buf, digits[:n]...)
+		buf = append(buf, '.')
+		buf = append(buf, digits[n:]...)
+	case -6 < n && n <= 0:
+		buf = append(buf, '0', '.')
+		for i := n; i < 0; i++ {
+			buf = append(buf, '0')
+		}
+		buf = append(buf, digits...)
+	default:
+		buf = append(buf, digits[0])
+		if k > 1 {
+			buf = append(buf, '.')
+			buf = append(buf, digits[1:]...)
+		}
+		buf = append(buf, 'e')
+		if n > 0 {
+			buf = append(buf, '+')
+		}
+		buf = strconv.AppendInt(buf, int64(n-1), 10)
+	}
+	return buf
+}
@@ -0,0 +1,164 @@
+package jwriter
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const lowerHexDigits = "0123456789abcdef"
+
+// StringEscaping is a set of flags that specify which characters a Writer should escape in strings
+// and property names, in addition to the quote mark, the backslash, and control characters, which
+// are always escaped. See Writer.SetStringEscaping.
+type StringEscaping int
+
+const (
+	// EscapeHTML escapes <, >, and & as \u003c, \u003e, and \u0026, so that the output can be
+	// embedded in an HTML script element.
+	EscapeHTML StringEscaping = 1 << iota
+
+	// EscapeLineTerminators escapes the characters U+2028 (line separator) and U+2029 (paragraph
+	// separator). These are valid in JSON strings, but not in JavaScript string literals prior to
+	// ECMAScript 2019, so they must be escaped if the output will be evaluated as JavaScript.
+	EscapeLineTerminators
+
+	// EscapeNonASCII escapes every character outside of the ASCII range, so that the output
+	// consists entirely of ASCII characters. Characters outside of the Basic Multilingual Plane are
+	// escaped as UTF-16 surrogate pairs, such as \ud83d\ude00.
+	EscapeNonASCII
+
+	// EscapeMinimal is the empty set of flags: only the characters that must be escaped in JSON are
+	// escaped.
+	EscapeMinimal StringEscaping = 0
+
+	// EscapeLikeEncodingJSON is the escaping that encoding/json uses by default.
+	EscapeLikeEncodingJSON = EscapeHTML | EscapeLineTerminators
+)
+
+// literalReplacementChar is used internally in canonical mode. Normally, invalid UTF-8 bytes are
+// replaced with the escape sequence \ufffd, but RFC 8785 does not escape any characters that do
+// not need to be escaped, so in that mode we write the U+FFFD character itself.
+const literalReplacementChar StringEscaping = 1 << 16
+
+// SetStringEscaping specifies which characters the Writer should escape in strings and property
+// names. In all cases, the quote mark, the backslash, and control characters are escaped, using
+// the short forms \b, \t, \n, \f, and \r where possible and otherwise \u00xx; all other escape
+// sequences are also written with lowercase hexadecimal digits.
+//
+// If this is not called, the escaping depends on the implementation: the default implementation
+// is the same as EscapeMinimal, whereas the easyjson implementation (see package documentation)
+// escapes some other characters. After calling SetStringEscaping, the output is the same with
+// either implementation.
+//
+// Invalid UTF-8 is handled as described in SetInvalidUTF8Policy. This has no effect in canonical
+// mode (see SetCanonical).
+func (w *Writer) SetStringEscaping(escaping StringEscaping) {
+	enc := w.encoding()
+	enc.encodeStrings = true
+	if !enc.canonical {
+		enc.escaping = escaping &^ literalReplacementChar
+	}
+}
+
+func (w *Writer) encodesStrings() bool {
+	return w.enc != nil && w.enc.encodeStrings
+}
+
+func (w *Writer) writeEncodedString(value string) {
+	w.enc.scratch = appendQuotedString(w.enc.scratch[:0], value, w.enc.escaping)
+	w.AddError(w.tw.Raw(w.enc.scratch))
+}
+
+func (w *Writer) writeEncodedPropertyName(name string) {
+	w.enc.scratch = append(appendQuotedString(w.enc.scratch[:0], name, w.enc.escaping), ':')
+	w.AddError(w.tw.Raw(w.enc.scratch))
+}
+
+// appendQuotedString appends a quoted JSON string, escaping the characters that JSON requires to be
+// escaped and any others that are specified by the escaping flags. Each invalid UTF-8 byte is
+// replaced with the Unicode replacement character U+FFFD.
+//
+// With only the literalReplacementChar flag, this is the minimal escaping that RFC 8785 specifies.
+func appendQuotedString(buf []byte, s string, escaping StringEscaping) []byte {
+	buf = append(buf, '"')
+	buf = appendEscapedString(buf, s, escaping)
+	return append(buf, '"')
+}
+
+// appendEscapedString is the same as appendQuotedString without the quotes. It accepts either a
+// string or a byte slice, so that StringStream can use it without converting each chunk of data.
+func appendEscapedString[S string | []byte](buf []byte, s S, escaping StringEscaping) []byte {
+	start := 0
+	for i := 0; i < len(s); {
+		ch := s[i]
+		if ch < utf8.RuneSelf {
+			if ch >= ' ' && ch != '"' && ch != '\\' &&
+				(escaping&EscapeHTML == 0 || (ch != '<' && ch != '>' && ch != '&')) {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			buf = appendEscapedByte(buf, ch)
+			i++
+			start = i
+			continue
+		}
+		r, size := decodeRune(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buf = append(buf, s[start:i]...)
+			if escaping&literalReplacementChar != 0 {
+				buf = append(buf, string(utf8.RuneError)...)
+			} else {
+				buf = appendEscapedRune(buf, utf8.RuneError)
+			}
+			start = i + size
+		case escaping&EscapeNonASCII != 0,
+			escaping&EscapeLineTerminators != 0 && (r == '\u2028' || r == '\u2029'):
+			buf = append(buf, s[start:i]...)
+			buf = appendEscapedRune(buf, r)
+			start = i + size
+		}
+		i += size
+	}
+	return append(buf, s[start:]...)
+}
+
+// decodeRune is the same as utf8.DecodeRuneInString, but for either a string or a byte slice. We
+// only convert as many bytes as a character can have, so the conversion does not allocate.
+func decodeRune[S string | []byte](s S) (rune, int) {
+	if len(s) > utf8.UTFMax {
+		s = s[:utf8.UTFMax]
+	}
+	return utf8.DecodeRuneInString(string(s))
+}
+
+func appendEscapedByte(buf []byte, ch byte) []byte {
+	switch ch {
+	case '\b':
+		return append(buf, '\\', 'b')
+	case '\t':
+		return append(buf, '\\', 't')
+	case '\n':
+		return append(buf, '\\', 'n')
+	case '\f':
+		return append(buf, '\\', 'f')
+	case '\r':
+		return append(buf, '\\', 'r')
+	case '"', '\\':
+		return append(buf, '\\', ch)
+	default:
+		return append(buf, '\\', 'u', '0', '0', lowerHexDigits[ch>>4], lowerHexDigits[ch&0xf])
+	}
+}
+
+// appendEscapedRune appends a \u escape sequence for a character, or two of them for a surrogate
+// pair if the character is outside of the Basic Multilingual Plane.
+func appendEscapedRune(buf []byte, r rune) []byte {
+	if r >= 0x10000 {
+		high, low := utf16.EncodeRune(r)
+		return appendEscapedRune(appendEscapedRune(buf, high), low)
+	}
+	return append(buf, '\\', 'u',
+		lowerHexDigits[r>>12&0xf], lowerHexDigits[r>>8&0xf], lowerHexDigits[r>>4&0xf], lowerHexDigits[r&0xf])
+}
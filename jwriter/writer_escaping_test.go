@@ -0,0 +1,76 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEscapedString(escaping StringEscaping, value string) string {
+	w := NewWriter()
+	w.SetStringEscaping(escaping)
+	obj := w.Object()
+	obj.Name(value).String(value)
+	obj.End()
+	return string(w.Bytes())
+}
+
+func TestStringEscaping(t *testing.T) {
+	for _, p := range []struct {
+		name     string
+		escaping StringEscaping
+		value    string
+		expected string
+	}{
+		{"minimal", EscapeMinimal, "a\"\\/\b\t\n\f\r\x01\x1f", `"a\"\\/\b\t\n\f\r\u0001\u001f"`},
+		{"minimal", EscapeMinimal, "<é \u2028 😀>", "\"<é \u2028 😀>\""},
+		{"HTML", EscapeHTML, "<a&b>", `"\u003ca\u0026b\u003e"`},
+		{"HTML", EscapeHTML, "é\u2028", "\"é\u2028\""},
+		{"line terminators", EscapeLineTerminators, "a\u2028b\u2029c<é", "\"a\\u2028b\\u2029c<é\""},
+		{"non-ASCII", EscapeNonASCII, "aé€😀 <", `"a\u00e9\u20ac\ud83d\ude00 <"`},
+		{"non-ASCII", EscapeNonASCII, "\x7f\u0080\uffff\U0010ffff", "\"\x7f\\u0080\\uffff\\udbff\\udfff\""},
+		{"all", EscapeHTML | EscapeLineTerminators | EscapeNonASCII, "<é>", `"\u003c\u00e9\u003e"`},
+	} {
+		t.Run(p.name+" "+p.value, func(t *testing.T) {
+			assert.Equal(t, "{"+p.expected+":"+p.expected+"}", writeEscapedString(p.escaping, p.value))
+		})
+	}
+}
+
+func TestStringEscapingReplacesInvalidUTF8(t *testing.T) {
+	assert.Equal(t, `{"a\ufffdb":"a\ufffdb"}`, writeEscapedString(EscapeMinimal, "a\xffb"))
+	assert.Equal(t, `{"a\ufffdb":"a\ufffdb"}`, writeEscapedString(EscapeNonASCII, "a\xffb"))
+}
+
+func TestEscapeLikeEncodingJSON(t *testing.T) {
+	for _, value := range []string{
+		"", "abc", "a\"b\\c", "\t\n\r\x00\x1f", "<script>&amp;</script>", "é€😀", "\u2028\u2029",
+	} {
+		t.Run(value, func(t *testing.T) {
+			expected, err := json.Marshal(map[string]string{value: value})
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), writeEscapedString(EscapeLikeEncodingJSON, value))
+		})
+	}
+}
+
+func TestStringEscapingIsIgnoredInCanonicalMode(t *testing.T) {
+	w := NewWriter()
+	w.SetCanonical()
+	w.SetStringEscaping(EscapeNonASCII)
+	w.String("<é>")
+	assert.Equal(t, `"<é>"`, string(w.Bytes()))
+}
+
+func TestSetCanonicalResetsStringEscaping(t *testing.T) {
+	w := NewWriter()
+	w.SetStringEscaping(EscapeHTML)
+	w.SetCanonical()
+	obj := w.Object()
+	obj.Name("b<").String("<")
+	obj.Name("a>").String(">")
+	obj.End()
+	assert.Equal(t, `{"a>":">","b<":"<"}`, string(w.Bytes()))
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math/big"
 	"os"
+	"strings"
 )
 
 func ExampleNewWriter() {
@@ -128,3 +131,112 @@ func ExampleWriter_Raw() {
 	fmt.Println(string(w.Bytes()))
 	// Output: {"value":1}
 }
+
+func ExampleWriteSlice() {
+	w := NewWriter()
+	WriteSlice(&w, []string{"a", "b"}, (*Writer).String)
+	fmt.Println(string(w.Bytes()))
+	// Output: ["a","b"]
+}
+
+func ExampleWriteMap() {
+	w := NewWriter()
+	WriteMap(&w, map[string]int{"b": 2, "a": 1}, (*Writer).Int)
+	fmt.Println(string(w.Bytes()))
+	// Output: {"a":1,"b":2}
+}
+
+func ExampleWriteValue() {
+	value := struct {
+		Name  string         `json:"name"`
+		Tags  []string       `json:"tags,omitempty"`
+		Attrs map[string]int `json:"attrs"`
+	}{Name: "x", Attrs: map[string]int{"b": 2, "a": 1}}
+	w := NewWriter()
+	WriteValue(&w, value)
+	fmt.Println(string(w.Bytes()))
+	// Output: {"name":"x","attrs":{"a":1,"b":2}}
+}
+
+func ExampleWriter_Value() {
+	w := NewWriter()
+	w.Value(map[string]interface{}{"b": []interface{}{1.5, "x", nil}, "a": true})
+	fmt.Println(string(w.Bytes()))
+	// Output: {"a":true,"b":[1.5,"x",null]}
+}
+
+func ExampleWriter_SetIndentOptions() {
+	w := NewWriter()
+	w.SetIndentOptions(IndentOptions{Indent: "  ", SpaceAfterColon: true, MaxCompactArrayLength: 4})
+	w.Value(map[string]interface{}{"a": []interface{}{1.5, "x", nil}, "b": map[string]interface{}{"c": true}})
+	fmt.Println(string(w.Bytes()))
+	// Output: {
+	//   "a": [1.5, "x", null],
+	//   "b": {
+	//     "c": true
+	//   }
+	// }
+}
+
+func ExampleWriter_SetCanonical() {
+	w := NewWriter()
+	w.SetCanonical()
+	obj := w.Object()
+	obj.Name("b").Float64(1e21)
+	obj.Name("a").Float64(0.5)
+	obj.Name("c").String("€<\n>")
+	obj.End()
+	fmt.Println(string(w.Bytes()))
+	// Output: {"a":0.5,"b":1e+21,"c":"€<\n>"}
+}
+
+func ExampleCanonicalize() {
+	data, err := Canonicalize([]byte(`{ "b": [1.0, 2E3], "a": "A" }`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(string(data))
+	// Output: {"a":"A","b":[1,2000]}
+}
+
+func ExampleWriter_BigInt() {
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	w := NewWriter()
+	w.BigInt(n)
+	fmt.Println(string(w.Bytes()))
+	// Output: 123456789012345678901234567890
+}
+
+func ExampleWriter_SetStrict() {
+	w := NewWriter()
+	w.SetStrict()
+	obj := w.Object()
+	obj.Name("a")
+	obj.Name("b").Int(1)
+	obj.End()
+	fmt.Println(w.Error())
+	// Output: invalid JSON structure: property name written without a value for the previous property
+}
+
+func ExampleWriter_SetSortKeys() {
+	w := NewWriter()
+	w.SetSortKeys()
+	obj := w.Object()
+	obj.Name("name").String("x")
+	obj.Name("id").Int(1)
+	obj.End()
+	fmt.Println(string(w.Bytes()))
+	// Output: {"id":1,"name":"x"}
+}
+
+func ExampleWriter_StringStream() {
+	w := NewWriter()
+	obj := w.Object()
+	s := obj.Name("log").StringStream()
+	_, _ = io.Copy(s, strings.NewReader("line 1\nline 2\n"))
+	_ = s.Close()
+	obj.End()
+	fmt.Println(string(w.Bytes()))
+	// Output: {"log":"line 1\nline 2\n"}
+}
@@ -0,0 +1,123 @@
+package jwriter
+
+import (
+	"math"
+	"strconv"
+)
+
+// These are the bounds of the range of float64 values that can be converted to int64. The
+// conversion is undefined for values outside of this range.
+const (
+	minInt64AsFloat = -(1 << 63)
+	maxInt64AsFloat = 1 << 63 // exclusive
+)
+
+// FloatFormat specifies how a Writer formats floating-point numbers that are written with Float64
+// or Float32. See Writer.SetFloatFormat. The zero value is the same as FloatFormatDefault.
+type FloatFormat struct {
+	kind      floatFormatKind
+	precision int
+}
+
+type floatFormatKind int
+
+const (
+	floatFormatDefault floatFormatKind = iota
+	floatFormatEncodingJSON
+	floatFormatES6
+	floatFormatFixed
+)
+
+//nolint:gochecknoglobals
+var (
+	// FloatFormatDefault writes integral values within the range of int64 as integers, and other
+	// values with the fewest digits that represent the same value, using an exponent if it is
+	// shorter, as in strconv.FormatFloat(value, 'g', -1, 64): for instance, 1e+21 or 1.5e-05.
+	FloatFormatDefault = FloatFormat{kind: floatFormatDefault}
+
+	// FloatFormatEncodingJSON produces the same output as encoding/json: the fewest digits that
+	// represent the same value, using an exponent only if the absolute value is less than 1e-6 or
+	// at least 1e21; for instance, 1e+21 or 0.000015.
+	FloatFormatEncodingJSON = FloatFormat{kind: floatFormatEncodingJSON}
+
+	// FloatFormatES6 produces the same output as JavaScript's Number.prototype.toString (as
+	// specified in ECMAScript 2015 and later), which is also what JSON.stringify uses: the fewest
+	// digits that represent the same value, using an exponent only if the absolute value is less
+	// than 1e-6 or at least 1e21, and writing the exponent with an explicit sign; for instance,
+	// 1e+21, 1e-7, or 0.000015.
+	FloatFormatES6 = FloatFormat{kind: floatFormatES6}
+)
+
+// FloatFormatFixed returns a FloatFormat that writes numbers without an exponent, with exactly the
+// specified number of digits after the decimal point, rounding if necessary; for instance, with
+// two digits, 1.005 is written as 1.00 and 3 as 3.00. If digits is zero or negative, numbers are
+// rounded to integers and there is no decimal point.
+func FloatFormatFixed(digits int) FloatFormat {
+	if digits < 0 {
+		digits = 0
+	}
+	return FloatFormat{kind: floatFormatFixed, precision: digits}
+}
+
+// SetFloatFormat specifies how the Writer should format the numbers that are written with Float64
+// or Float32. The default is FloatFormatDefault. This has no effect in canonical mode (see
+// SetCanonical), which always uses the ES6 format.
+//
+// For Float32, all of the formats except FloatFormatFixed use the fewest digits that represent the
+// same float32 value.
+func (w *Writer) SetFloatFormat(format FloatFormat) {
+	w.encoding().floatFormat = format
+}
+
+func (w *Writer) hasFloatFormat() bool {
+	return w.enc != nil && w.enc.floatFormat.kind != floatFormatDefault
+}
+
+// writeFormattedFloat writes a finite number in a format other than the default.
+func (w *Writer) writeFormattedFloat(value float64, bits int) {
+	format := w.enc.floatFormat
+	buf := w.enc.scratch[:0]
+	switch format.kind { //nolint:exhaustive
+	case floatFormatEncodingJSON:
+		buf = appendEncodingJSONFloat(buf, value, bits)
+	case floatFormatES6:
+		buf = appendESNumber(buf, value, bits)
+	case floatFormatFixed:
+		buf = strconv.AppendFloat(buf, value, 'f', format.precision, bits)
+	}
+	w.enc.scratch = buf
+	w.AddError(w.tw.Raw(buf))
+}
+
+// appendEncodingJSONFloat uses the same logic as the float encoder in encoding/json.
+func appendEncodingJSONFloat(buf []byte, value float64, bits int) []byte {
+	abs := math.Abs(value)
+	fmt := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			fmt = 'e'
+		}
+	}
+	start := len(buf)
+	buf = strconv.AppendFloat(buf, value, fmt, -1, bits)
+	if fmt == 'e' {
+		// clean up e-09 to e-9
+		n := len(buf) - start
+		if n >= 4 && buf[start+n-4] == 'e' && buf[start+n-3] == '-' && buf[start+n-2] == '0' {
+			buf[start+n-2] = buf[start+n-1]
+			buf = buf[:start+n-1]
+		}
+	}
+	return buf
+}
+
+// floatToInt64 returns the integer value of a float64 if it is integral and within the range of
+// int64.
+func floatToInt64(value float64) (int64, bool) {
+	if value >= minInt64AsFloat && value < maxInt64AsFloat {
+		i := int64(value)
+		return i, float64(i) == value
+	}
+	return 0, false
+}
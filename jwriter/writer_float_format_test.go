@@ -0,0 +1,155 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var floatFormatTestValues = []float64{ //nolint:gochecknoglobals
+	0, 1, -1, 0.1, -0.5, 1.5, 3.14159, 100, 1e6, 1e20, 1e21, 1.5e21, 1e100, 123456789012345678,
+	1e-6, 1e-7, 1.5e-7, 0.000015, 5e-324, math.MaxFloat64, -math.MaxFloat64, 1 << 62, 1 << 63,
+	-(1 << 63), 333333333.3333333,
+}
+
+func writeFloat(t *testing.T, format FloatFormat, write func(*Writer)) string {
+	w := NewWriter()
+	w.SetFloatFormat(format)
+	write(&w)
+	require.NoError(t, w.Error())
+	return string(w.Bytes())
+}
+
+func TestFloatFormatDefault(t *testing.T) {
+	for _, p := range []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0"},
+		{1.5, "1.5"},
+		{-100, "-100"},
+		{1e6, "1000000"},
+		{1 << 62, "4611686018427387904"},
+		{-(1 << 63), "-9223372036854775808"},
+		{1 << 63, "9.223372036854776e+18"},
+		{1e21, "1e+21"},
+		{1e100, "1e+100"},
+		{1.5e-7, "1.5e-07"},
+	} {
+		t.Run(p.expected, func(t *testing.T) {
+			assert.Equal(t, p.expected, writeFloat(t, FloatFormatDefault, func(w *Writer) { w.Float64(p.value) }))
+			assert.Equal(t, p.expected, writeFloat(t, FloatFormat{}, func(w *Writer) { w.Float64(p.value) }))
+		})
+	}
+}
+
+func TestFloat32FormatDefault(t *testing.T) {
+	for _, p := range []struct {
+		value    float32
+		expected string
+	}{
+		{0, "0"},
+		{0.1, "0.1"},
+		{1e10, "10000000000"},
+		{1e30, "1e+30"},
+	} {
+		t.Run(p.expected, func(t *testing.T) {
+			assert.Equal(t, p.expected, writeFloat(t, FloatFormatDefault, func(w *Writer) { w.Float32(p.value) }))
+		})
+	}
+}
+
+func TestFloatFormatEncodingJSON(t *testing.T) {
+	for _, value := range floatFormatTestValues {
+		expected, err := json.Marshal(value)
+		require.NoError(t, err)
+		t.Run(string(expected), func(t *testing.T) {
+			assert.Equal(t, string(expected), writeFloat(t, FloatFormatEncodingJSON, func(w *Writer) { w.Float64(value) }))
+		})
+
+		value32 := float32(value)
+		if math.IsInf(float64(value32), 0) {
+			continue
+		}
+		expected32, err := json.Marshal(value32)
+		require.NoError(t, err)
+		t.Run("float32 "+string(expected32), func(t *testing.T) {
+			assert.Equal(t, string(expected32),
+				writeFloat(t, FloatFormatEncodingJSON, func(w *Writer) { w.Float32(value32) }))
+		})
+	}
+}
+
+func TestFloatFormatES6(t *testing.T) {
+	// The expected values are the output of Number.prototype.toString in JavaScript.
+	for _, p := range []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0"},
+		{math.Copysign(0, -1), "0"},
+		{1, "1"},
+		{-0.5, "-0.5"},
+		{1e20, "100000000000000000000"},
+		{1e21, "1e+21"},
+		{1.5e21, "1.5e+21"},
+		{123456789012345678, "123456789012345680"},
+		{1e-6, "0.000001"},
+		{1e-7, "1e-7"},
+		{1.5e-7, "1.5e-7"},
+		{5e-324, "5e-324"},
+		{math.MaxFloat64, "1.7976931348623157e+308"},
+		{1 << 63, "9223372036854776000"},
+	} {
+		t.Run(p.expected, func(t *testing.T) {
+			assert.Equal(t, p.expected, writeFloat(t, FloatFormatES6, func(w *Writer) { w.Float64(p.value) }))
+		})
+	}
+	assert.Equal(t, "0.1", writeFloat(t, FloatFormatES6, func(w *Writer) { w.Float32(0.1) }))
+	assert.Equal(t, "1e+30", writeFloat(t, FloatFormatES6, func(w *Writer) { w.Float32(1e30) }))
+}
+
+func TestFloatFormatFixed(t *testing.T) {
+	for _, p := range []struct {
+		digits   int
+		value    float64
+		expected string
+	}{
+		{2, 0, "0.00"},
+		{2, 3, "3.00"},
+		{2, 1.005, "1.00"},
+		{2, -1.5, "-1.50"},
+		{2, 1e21, "1000000000000000000000.00"},
+		{2, 1e-7, "0.00"},
+		{0, 2.5, "2"},
+		{0, 3.5, "4"},
+		{-1, 3.25, "3"},
+		{4, 1.0 / 3, "0.3333"},
+	} {
+		t.Run(p.expected, func(t *testing.T) {
+			assert.Equal(t, p.expected, writeFloat(t, FloatFormatFixed(p.digits), func(w *Writer) { w.Float64(p.value) }))
+		})
+	}
+}
+
+func TestFloatFormatAppliesToArrayState(t *testing.T) {
+	out := writeFloat(t, FloatFormatFixed(1), func(w *Writer) {
+		arr := w.Array()
+		arr.Float64(1)
+		arr.Float32(2.25)
+		arr.Int(3)
+		arr.End()
+	})
+	assert.Equal(t, `[1.0,2.2,3]`, out)
+}
+
+func TestFloatFormatIsIgnoredInCanonicalMode(t *testing.T) {
+	w := NewWriter()
+	w.SetFloatFormat(FloatFormatFixed(2))
+	w.SetCanonical()
+	w.Float64(1.5)
+	assert.Equal(t, "1.5", string(w.Bytes()))
+}
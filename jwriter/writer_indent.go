@@ -0,0 +1,162 @@
+package jwriter
+
+import "encoding/json"
+
+var compactArraySeparator = []byte(", ") //nolint:gochecknoglobals
+
+// IndentOptions specifies how a Writer should format its output on multiple lines. See
+// Writer.SetIndentOptions.
+type IndentOptions struct {
+	// Prefix is written at the beginning of each line after the first.
+	Prefix string
+
+	// Indent is written at the beginning of each line, after Prefix, once for each level of nesting.
+	Indent string
+
+	// SpaceAfterColon causes a space to be written between each object property name and its value.
+	SpaceAfterColon bool
+
+	// MaxCompactArrayLength, if greater than zero, causes an array to be written on a single line,
+	// with a space after each comma, if it has no more than this many elements and none of them are
+	// arrays or objects: for instance, [1, 2, 3].
+	MaxCompactArrayLength int
+}
+
+// indentState holds the Writer's state for indented output. It is only allocated if indentation
+// has been enabled, so it adds no overhead to compact output.
+type indentState struct {
+	options IndentOptions
+
+	// line is a newline and prefix followed by enough copies of the indent string for the deepest
+	// level of nesting so far; we write a slice of it at the start of each line.
+	line []byte
+
+	// If pending is true, the Writer is within an array that might be written in compact form, so
+	// its elements are being written to a temporary tokenWriter instead of the real one (saved) until
+	// we know whether the array is short enough. elementStarts are the offsets of the elements in the
+	// temporary buffer.
+	pending       bool
+	saved         tokenWriter
+	elementStarts []int
+}
+
+// SetIndent causes the Writer to format its output on multiple lines, in the same way as
+// json.MarshalIndent: each array element or object property begins on a new line, which starts
+// with prefix followed by one copy of indent for each level of nesting, and there is a space
+// after each colon. This is a shortcut for SetIndentOptions.
+//
+// This should be called before anything is written.
+func (w *Writer) SetIndent(prefix, indent string) {
+	w.SetIndentOptions(IndentOptions{Prefix: prefix, Indent: indent, SpaceAfterColon: true})
+}
+
+// SetIndentOptions causes the Writer to format its output on multiple lines, as described by
+// IndentOptions. Empty arrays and objects are still written as [] and {}.
+//
+// If MaxCompactArrayLength is set, the elements of each array are buffered in memory until it is
+// known whether the array can be written in compact form; this does not happen for more than
+// MaxCompactArrayLength elements at a time.
+//
+// Arrays and objects written with Raw are parsed and indented in the same way as all other output,
+// so they must be valid JSON; scalar values written with Raw are still written as-is.
+//
+// This should be called before anything is written.
+func (w *Writer) SetIndentOptions(options IndentOptions) {
+	w.indent = &indentState{
+		options: options,
+		line:    append([]byte{'\n'}, options.Prefix...),
+	}
+}
+
+func (w *Writer) writeNewline(depth int) {
+	ind := w.indent
+	n := 1 + len(ind.options.Prefix) + depth*len(ind.options.Indent)
+	for len(ind.line) < n {
+		ind.line = append(ind.line, ind.options.Indent...)
+	}
+	w.AddError(w.tw.Raw(ind.line[:n]))
+}
+
+func (w *Writer) afterPropertyName() {
+	if w.indent.options.SpaceAfterColon {
+		w.AddError(w.tw.Delimiter(' '))
+	}
+}
+
+// beginPendingArray is called after writing the opening delimiter of an array, if arrays might be
+// written in compact form.
+func (w *Writer) beginPendingArray() {
+	ind := w.indent
+	ind.pending = true
+	ind.saved = w.tw
+	ind.elementStarts = ind.elementStarts[:0]
+	w.tw = w.tw.newTemporary()
+}
+
+// beforePendingElement is called before writing an element of an array that might be written in
+// compact form. If it returns false, the array is too long, so it has been switched to the
+// regular format.
+func (w *Writer) beforePendingElement() bool {
+	ind := w.indent
+	if len(ind.elementStarts) == ind.options.MaxCompactArrayLength {
+		w.expandPendingArray()
+		return false
+	}
+	ind.elementStarts = append(ind.elementStarts, w.tw.Size())
+	return true
+}
+
+// expandPendingArray writes the elements that were buffered so far for an array that turns out not
+// to be suitable for compact form, each on its own line, and resumes writing to the real output.
+func (w *Writer) expandPendingArray() {
+	buffered := w.endPendingArray()
+	for i := range w.indent.elementStarts {
+		if i > 0 {
+			w.AddError(w.tw.Delimiter(','))
+		}
+		w.writeNewline(w.state.depth)
+		w.AddError(w.tw.Raw(w.indent.element(buffered, i)))
+	}
+	w.state.arrayHasItems = len(w.indent.elementStarts) != 0
+}
+
+// writeCompactArray writes the elements that were buffered for an array on a single line.
+func (w *Writer) writeCompactArray() {
+	buffered := w.endPendingArray()
+	for i := range w.indent.elementStarts {
+		if i > 0 {
+			w.AddError(w.tw.Raw(compactArraySeparator))
+		}
+		w.AddError(w.tw.Raw(w.indent.element(buffered, i)))
+	}
+}
+
+func (w *Writer) endPendingArray() []byte {
+	buffered := w.tw.Bytes()
+	w.tw = w.indent.saved
+	w.indent.saved = tokenWriter{}
+	w.indent.pending = false
+	return buffered
+}
+
+func (ind *indentState) element(buffered []byte, i int) json.RawMessage {
+	if i+1 < len(ind.elementStarts) {
+		return buffered[ind.elementStarts[i]:ind.elementStarts[i+1]]
+	}
+	return buffered[ind.elementStarts[i]:]
+}
+
+// isScalarJSON returns true if a pre-encoded JSON value is not an array or object.
+func isScalarJSON(value json.RawMessage) bool {
+	for _, ch := range value {
+		switch ch {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case '[', '{':
+			return false
+		default:
+			return true
+		}
+	}
+	return true
+}
@@ -0,0 +1,260 @@
+package jwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeIndentTestData(w *Writer) {
+	obj := w.Object()
+	obj.Name("a").Int(1)
+	arr := obj.Name("b").Array()
+	arr.Bool(true)
+	arr.String("x")
+	inner := arr.Object()
+	inner.Name("c").Null()
+	inner.End()
+	emptyArr := arr.Array()
+	emptyArr.End()
+	emptyObj := arr.Object()
+	emptyObj.End()
+	nested := arr.Array()
+	nested.Int(2)
+	nested.Int(3)
+	nested.End()
+	arr.End()
+	obj.Name("d").Raw(json.RawMessage(`4.0`))
+	obj.End()
+}
+
+func TestIndentMatchesMarshalIndent(t *testing.T) {
+	compact := NewWriter()
+	writeIndentTestData(&compact)
+	require.NoError(t, compact.Error())
+	compactBytes := compact.Bytes()
+
+	for _, p := range []struct {
+		prefix, indent string
+	}{
+		{"", "  "},
+		{"", "\t"},
+		{">", "  "},
+		{"", ""},
+	} {
+		t.Run(p.prefix+"|"+p.indent, func(t *testing.T) {
+			var expected bytes.Buffer
+			require.NoError(t, json.Indent(&expected, compactBytes, p.prefix, p.indent))
+
+			w := NewWriter()
+			w.SetIndent(p.prefix, p.indent)
+			writeIndentTestData(&w)
+			require.NoError(t, w.Error())
+			assert.Equal(t, expected.String(), string(w.Bytes()))
+		})
+	}
+}
+
+func TestIndentRawValues(t *testing.T) {
+	raw := json.RawMessage(`{"a": [1, {"b": 2.50}],` + "\n" + `"c": {}}`)
+	w := NewWriter()
+	w.SetIndent(">", "  ")
+	arr := w.Array()
+	arr.Raw(raw)
+	arr.Raw(json.RawMessage(`"x"`))
+	arr.End()
+	require.NoError(t, w.Error())
+
+	var expected bytes.Buffer
+	require.NoError(t, json.Indent(&expected, []byte(`[{"a":[1,{"b":2.50}],"c":{}},"x"]`), ">", "  "))
+	assert.Equal(t, expected.String(), string(w.Bytes()))
+}
+
+func TestIndentInvalidRawValue(t *testing.T) {
+	w := NewWriter()
+	w.SetIndent("", "  ")
+	w.Raw(json.RawMessage(`{"a":`))
+	assert.Error(t, w.Error())
+}
+
+func TestIndentWithoutSpaceAfterColon(t *testing.T) {
+	w := NewWriter()
+	w.SetIndentOptions(IndentOptions{Indent: "  "})
+	obj := w.Object()
+	obj.Name("a").Int(1)
+	inner := obj.Name("b").Object()
+	inner.End()
+	obj.End()
+	assert.Equal(t, "{\n  \"a\":1,\n  \"b\":{}\n}", string(w.Bytes()))
+}
+
+func TestIndentScalarValue(t *testing.T) {
+	w := NewWriter()
+	w.SetIndent("  ", "  ")
+	w.String("x")
+	assert.Equal(t, `"x"`, string(w.Bytes()))
+}
+
+func TestIndentCompactArrays(t *testing.T) {
+	for _, p := range []struct {
+		name     string
+		write    func(w *Writer)
+		expected string
+	}{
+		{
+			"empty",
+			func(w *Writer) {
+				arr := w.Array()
+				arr.End()
+			},
+			`[]`,
+		},
+		{
+			"short scalar array",
+			func(w *Writer) {
+				arr := w.Array()
+				arr.Int(1)
+				arr.String("a, b")
+				arr.Null()
+				arr.End()
+			},
+			`[1, "a, b", null]`,
+		},
+		{
+			"too many elements",
+			func(w *Writer) {
+				arr := w.Array()
+				for i := 1; i <= 4; i++ {
+					arr.Int(i)
+				}
+				arr.End()
+			},
+			"[\n  1,\n  2,\n  3,\n  4\n]",
+		},
+		{
+			"nested array",
+			func(w *Writer) {
+				arr := w.Array()
+				arr.Int(1)
+				inner := arr.Array()
+				inner.Int(2)
+				inner.Int(3)
+				inner.End()
+				empty := arr.Array()
+				empty.End()
+				arr.End()
+			},
+			"[\n  1,\n  [2, 3],\n  []\n]",
+		},
+		{
+			"nested object",
+			func(w *Writer) {
+				arr := w.Array()
+				arr.Int(1)
+				obj := arr.Object()
+				obj.Name("a").Int(2)
+				obj.End()
+				arr.End()
+			},
+			"[\n  1,\n  {\n    \"a\": 2\n  }\n]",
+		},
+		{
+			"object as first element",
+			func(w *Writer) {
+				arr := w.Array()
+				obj := arr.Object()
+				obj.End()
+				arr.Int(1)
+				arr.End()
+			},
+			"[\n  {},\n  1\n]",
+		},
+		{
+			"array in object",
+			func(w *Writer) {
+				obj := w.Object()
+				arr := obj.Name("a").Array()
+				arr.Bool(true)
+				arr.Bool(false)
+				arr.End()
+				obj.Name("b").Int(1)
+				obj.End()
+			},
+			"{\n  \"a\": [true, false],\n  \"b\": 1\n}",
+		},
+		{
+			"raw scalar",
+			func(w *Writer) {
+				arr := w.Array()
+				arr.Raw(json.RawMessage(`1.0`))
+				arr.Raw(json.RawMessage(` "x"`))
+				arr.End()
+			},
+			`[1.0,  "x"]`,
+		},
+		{
+			"raw array",
+			func(w *Writer) {
+				arr := w.Array()
+				arr.Int(1)
+				arr.Raw(json.RawMessage(` [2]`))
+				arr.End()
+			},
+			"[\n  1,\n  [2]\n]",
+		},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			w := NewWriter()
+			w.SetIndentOptions(IndentOptions{Indent: "  ", SpaceAfterColon: true, MaxCompactArrayLength: 3})
+			p.write(&w)
+			require.NoError(t, w.Error())
+			assert.Equal(t, p.expected, string(w.Bytes()))
+		})
+	}
+}
+
+func TestIndentFlushWhileArrayIsPending(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewStreamingWriter(&buf, 1000)
+	w.SetIndentOptions(IndentOptions{Indent: "  ", MaxCompactArrayLength: 3})
+	obj := w.Object()
+	arr := obj.Name("a").Array()
+	arr.Int(1)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "{\n  \"a\":[", buf.String())
+	arr.End()
+	obj.End()
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "{\n  \"a\":[1]\n}", buf.String())
+}
+
+func TestIndentWithStreamingWriter(t *testing.T) {
+	var expected bytes.Buffer
+	compact := NewWriter()
+	writeIndentTestData(&compact)
+	require.NoError(t, json.Indent(&expected, compact.Bytes(), "", "  "))
+
+	var buf bytes.Buffer
+	w := NewStreamingWriter(&buf, 10)
+	w.SetIndentOptions(IndentOptions{Indent: "  ", SpaceAfterColon: true, MaxCompactArrayLength: 2})
+	writeIndentTestData(&w)
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{
+  "a": 1,
+  "b": [
+    true,
+    "x",
+    {
+      "c": null
+    },
+    [],
+    {},
+    [2, 3]
+  ],
+  "d": 4.0
+}`, buf.String())
+}
@@ -43,3 +43,33 @@ func TestNewWriterFromEasyJSONWriter(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedOutput, string(output))
 }
+
+func TestNewWriterFromEasyJSONWriterKeepsSettingsInCompactArrays(t *testing.T) {
+	ejw := ejwriter.Writer{NoEscapeHTML: true}
+	writer := NewWriterFromEasyJSONWriter(&ejw)
+	writer.SetIndentOptions(IndentOptions{Indent: "  ", MaxCompactArrayLength: 2})
+	arr := writer.Array()
+	arr.String("<a>")
+	arr.String("<b>")
+	arr.End()
+	require.NoError(t, writer.Error())
+
+	output, err := ejw.BuildBytes()
+	require.NoError(t, err)
+	require.Equal(t, `["<a>", "<b>"]`, string(output))
+}
+
+func TestNewWriterFromEasyJSONWriterKeepsSettingsWhenSortingKeys(t *testing.T) {
+	ejw := ejwriter.Writer{NoEscapeHTML: true}
+	writer := NewWriterFromEasyJSONWriter(&ejw)
+	writer.SetSortKeys()
+	obj := writer.Object()
+	obj.Name("b").String("<b>")
+	obj.Name("a").String("<a>")
+	obj.End()
+	require.NoError(t, writer.Error())
+
+	output, err := ejw.BuildBytes()
+	require.NoError(t, err)
+	require.Equal(t, `{"a":"<a>","b":"<b>"}`, string(output))
+}
@@ -0,0 +1,81 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/launchdarkly/go-jsonstream/v3/internal/ordered"
+)
+
+// Value writes a JSON value that is represented by a generic Go value, such as the result of
+// jreader.Reader.ReadInterface, or of unmarshaling JSON into an empty interface with encoding/json:
+//
+// - nil is written as a JSON null.
+//
+// - A bool, float64, or string is written as the corresponding JSON type. A json.Number is written
+// as a JSON number, with its exact text.
+//
+// - A []interface{} is written as a JSON array, and a map[string]interface{} is written as a JSON
+// object with its properties sorted by name; the elements and property values are written with
+// Value. A jreader.OrderedObject is written as a JSON object with its properties in their original
+// order.
+//
+// Any other value, including numeric types other than float64, is written with WriteValue. As in
+// WriteValue, if an array or object contains itself, the Writer enters a failed state with a
+// CycleError.
+func (w *Writer) Value(value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		w.Null()
+	case bool:
+		w.Bool(v)
+	case float64:
+		w.Float64(v)
+	case string:
+		w.String(v)
+	case json.Number:
+		w.Number(v)
+	case []interface{}:
+		if v == nil {
+			w.Null()
+			return
+		}
+		if rv := reflect.ValueOf(value); w.enterReference(rv) {
+			arr := w.Array()
+			for _, element := range v {
+				if w.err != nil {
+					break
+				}
+				w.Value(element)
+			}
+			arr.End()
+			w.leaveReference(rv)
+		}
+	case map[string]interface{}:
+		if v == nil {
+			w.Null()
+		} else if rv := reflect.ValueOf(value); w.enterReference(rv) {
+			WriteMap(w, v, (*Writer).Value)
+			w.leaveReference(rv)
+		}
+	case ordered.Object:
+		w.writeOrderedObject(v)
+	default:
+		WriteValue(w, value)
+	}
+}
+
+func (w *Writer) writeOrderedObject(o ordered.Object) {
+	if o == nil {
+		w.Null()
+		return
+	}
+	obj := w.Object()
+	for _, prop := range o {
+		if w.err != nil {
+			break
+		}
+		obj.Name(prop.Name).Value(prop.Value)
+	}
+	obj.End()
+}
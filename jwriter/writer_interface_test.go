@@ -0,0 +1,83 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/launchdarkly/go-jsonstream/v3/jreader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValue(t *testing.T) {
+	t.Run("generic tree", func(t *testing.T) {
+		value := map[string]interface{}{
+			"s": "x",
+			"b": true,
+			"n": 1.5,
+			"a": []interface{}{float64(1), nil, []interface{}{}},
+			"o": map[string]interface{}{"z": "1", "y": map[string]interface{}{}},
+		}
+		w := NewWriter()
+		w.Value(value)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"a":[1,null,[]],"b":true,"n":1.5,"o":{"y":{},"z":"1"},"s":"x"}`, string(w.Bytes()))
+	})
+
+	t.Run("json.Number and ordered objects", func(t *testing.T) {
+		value := jreader.OrderedObject{
+			{Name: "z", Value: json.Number("12345678901234567890")},
+			{Name: "a", Value: jreader.OrderedObject{}},
+		}
+		w := NewWriter()
+		w.Value(value)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"z":12345678901234567890,"a":{}}`, string(w.Bytes()))
+	})
+
+	t.Run("nil containers", func(t *testing.T) {
+		w := NewWriter()
+		arr := w.Array()
+		w.Value(nil)
+		w.Value([]interface{}(nil))
+		w.Value(map[string]interface{}(nil))
+		w.Value(jreader.OrderedObject(nil))
+		arr.End()
+		require.NoError(t, w.Error())
+		assert.Equal(t, `[null,null,null,null]`, string(w.Bytes()))
+	})
+
+	t.Run("other types use WriteValue", func(t *testing.T) {
+		w := NewWriter()
+		w.Value([]interface{}{3, uint8(4), []string{"a"}, struct{ A int }{5}})
+		require.NoError(t, w.Error())
+		assert.Equal(t, `[3,4,["a"],{"A":5}]`, string(w.Bytes()))
+	})
+
+	t.Run("cycles", func(t *testing.T) {
+		a := []interface{}{nil}
+		a[0] = a
+		w := NewWriter()
+		w.Value(a)
+		assert.Equal(t, CycleError{Type: reflect.TypeOf(a)}, w.Error())
+
+		m := map[string]interface{}{}
+		m["m"] = []interface{}{m}
+		w = NewWriter()
+		w.Value(m)
+		assert.Equal(t, CycleError{Type: reflect.TypeOf(m)}, w.Error())
+	})
+
+	t.Run("round trip with ReadInterface", func(t *testing.T) {
+		data := `{"a":[1.5,"x",{"c":null,"b":false}],"z":12345678901234567890}`
+		r := jreader.NewReader([]byte(data))
+		value := r.ReadInterfaceWithOptions(jreader.InterfaceOptions{UseNumber: true, OrderedObjects: true})
+		require.NoError(t, r.Error())
+		w := NewWriter()
+		w.Value(value)
+		require.NoError(t, w.Error())
+		assert.Equal(t, data, string(w.Bytes()))
+	})
+}
@@ -0,0 +1,117 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// NonFiniteNumberPolicy specifies what a Writer does with a NaN or infinite number, which cannot be
+// represented as a JSON number. See Writer.SetNonFiniteNumberPolicy.
+type NonFiniteNumberPolicy int
+
+const (
+	// NonFiniteNumberError means that writing a NaN or infinite number causes the Writer to enter a
+	// failed state with an UnsupportedValueError. This is the default.
+	NonFiniteNumberError NonFiniteNumberPolicy = iota
+
+	// NonFiniteNumberNull means that a NaN or infinite number is written as a JSON null, as is done
+	// by JavaScript's JSON.stringify.
+	NonFiniteNumberNull
+
+	// NonFiniteNumberString means that a NaN or infinite number is written as one of the JSON
+	// strings "NaN", "Infinity", or "-Infinity", which are the names of these values in JavaScript.
+	NonFiniteNumberString
+)
+
+// SetNonFiniteNumberPolicy specifies what the Writer should do if a NaN or infinite value is
+// passed to Float64, Float32, or BigFloat. The default is NonFiniteNumberError.
+func (w *Writer) SetNonFiniteNumberPolicy(policy NonFiniteNumberPolicy) {
+	w.encoding().nonFinite = policy
+}
+
+// Number writes a JSON numeric value to the output, with exactly the same text as the json.Number,
+// so no precision is lost. An empty json.Number is written as 0, as encoding/json does. If the
+// string is not a valid JSON number, the Writer enters a failed state.
+//
+// In canonical mode (see SetCanonical), the number is converted to a float64 and formatted as by
+// Float64, since RFC 8785 treats all numbers as 64-bit floating-point values.
+func (w *Writer) Number(value json.Number) {
+	if value == "" {
+		w.Int(0)
+		return
+	}
+	if !isValidNumber(string(value)) {
+		w.AddError(fmt.Errorf("invalid number literal %q", string(value)))
+		return
+	}
+	if w.isCanonical() {
+		f, _ := strconv.ParseFloat(string(value), 64) // if out of range, this is infinite
+		w.Float64(f)
+		return
+	}
+	w.Raw(json.RawMessage(value))
+}
+
+// BigInt writes a JSON numeric value to the output, with all of its digits. A nil pointer is
+// written as a JSON null.
+func (w *Writer) BigInt(value *big.Int) {
+	if value == nil {
+		w.Null()
+		return
+	}
+	if w.isCanonical() {
+		f, _ := new(big.Float).SetInt(value).Float64()
+		w.Float64(f)
+		return
+	}
+	w.Raw(value.Append(nil, 10))
+}
+
+// BigFloat writes a JSON numeric value to the output, using the fewest digits that represent the
+// same value at its precision. A nil pointer is written as a JSON null. An infinite value is treated
+// the same as in Float64.
+func (w *Writer) BigFloat(value *big.Float) {
+	if value == nil {
+		w.Null()
+		return
+	}
+	if value.IsInf() {
+		w.writeNonFinite(math.Inf(value.Sign()))
+		return
+	}
+	if w.isCanonical() {
+		f, _ := value.Float64()
+		w.Float64(f)
+		return
+	}
+	w.Raw(value.Append(nil, 'g', -1))
+}
+
+func isNonFinite(value float64) bool {
+	return math.IsNaN(value) || math.IsInf(value, 0)
+}
+
+func (w *Writer) writeNonFinite(value float64) {
+	policy := NonFiniteNumberError
+	if w.enc != nil {
+		policy = w.enc.nonFinite
+	}
+	switch policy {
+	case NonFiniteNumberNull:
+		w.Null()
+	case NonFiniteNumberString:
+		switch {
+		case math.IsNaN(value):
+			w.String("NaN")
+		case value > 0:
+			w.String("Infinity")
+		default:
+			w.String("-Infinity")
+		}
+	default:
+		w.AddError(UnsupportedValueError{Value: value})
+	}
+}
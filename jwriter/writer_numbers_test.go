@@ -0,0 +1,159 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericTypes(t *testing.T) {
+	hugeInt, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for _, p := range []struct {
+		name     string
+		write    func(*Writer)
+		expected string
+	}{
+		{"Int64 zero", func(w *Writer) { w.Int64(0) }, `0`},
+		{"Int64 max", func(w *Writer) { w.Int64(math.MaxInt64) }, `9223372036854775807`},
+		{"Int64 min", func(w *Writer) { w.Int64(math.MinInt64) }, `-9223372036854775808`},
+		{"Uint64 zero", func(w *Writer) { w.Uint64(0) }, `0`},
+		{"Uint64 max", func(w *Writer) { w.Uint64(math.MaxUint64) }, `18446744073709551615`},
+		{"Float32 zero", func(w *Writer) { w.Float32(0) }, `0`},
+		{"Float32 integer", func(w *Writer) { w.Float32(3) }, `3`},
+		{"Float32 fraction", func(w *Writer) { w.Float32(0.1) }, `0.1`},
+		{"Float32 negative", func(w *Writer) { w.Float32(-1.5) }, `-1.5`},
+		{"Float32 max", func(w *Writer) { w.Float32(math.MaxFloat32) }, `3.4028235e+38`},
+		{"Number integer", func(w *Writer) { w.Number("12345678901234567890") }, `12345678901234567890`},
+		{"Number fraction", func(w *Writer) { w.Number("-1.50e+3") }, `-1.50e+3`},
+		{"Number empty", func(w *Writer) { w.Number("") }, `0`},
+		{"BigInt", func(w *Writer) { w.BigInt(hugeInt) }, `-123456789012345678901234567890`},
+		{"BigInt zero", func(w *Writer) { w.BigInt(new(big.Int)) }, `0`},
+		{"BigInt nil", func(w *Writer) { w.BigInt(nil) }, `null`},
+		{"BigFloat", func(w *Writer) { w.BigFloat(big.NewFloat(1.5)) }, `1.5`},
+		{"BigFloat exponent", func(w *Writer) { w.BigFloat(big.NewFloat(-2.5e100)) }, `-2.5e+100`},
+		{"BigFloat integer", func(w *Writer) { w.BigFloat(new(big.Float).SetInt(hugeInt)) },
+			`-1.2345678901234567890123456789e+29`},
+		{"BigFloat nil", func(w *Writer) { w.BigFloat(nil) }, `null`},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			w := NewWriter()
+			p.write(&w)
+			require.NoError(t, w.Error())
+			out := w.Bytes()
+			assert.Equal(t, p.expected, string(out))
+			var parsed interface{}
+			assert.NoError(t, json.Unmarshal(out, &parsed))
+		})
+	}
+}
+
+func TestNumericTypesInArray(t *testing.T) {
+	w := NewWriter()
+	arr := w.Array()
+	arr.Int64(-1)
+	arr.Uint64(2)
+	arr.Float32(0.25)
+	arr.Number("3.0")
+	arr.BigInt(big.NewInt(4))
+	arr.BigFloat(big.NewFloat(5.5))
+	arr.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `[-1,2,0.25,3.0,4,5.5]`, string(w.Bytes()))
+}
+
+func TestInvalidNumberIsError(t *testing.T) {
+	for _, value := range []json.Number{"x", "01", "1.", "+1", "1e", "NaN", "1 "} {
+		t.Run(string(value), func(t *testing.T) {
+			w := NewWriter()
+			w.Number(value)
+			assert.Error(t, w.Error())
+			assert.Len(t, w.Bytes(), 0)
+		})
+	}
+}
+
+func TestInfiniteBigFloatIsError(t *testing.T) {
+	w := NewWriter()
+	w.BigFloat(new(big.Float).SetInf(true))
+	require.Error(t, w.Error())
+	assert.IsType(t, UnsupportedValueError{}, w.Error())
+}
+
+func TestNumericTypesInCanonicalMode(t *testing.T) {
+	w := NewWriter()
+	w.SetCanonical()
+	arr := w.Array()
+	arr.Int64(math.MaxInt64)
+	arr.Uint64(math.MaxUint64)
+	arr.Float32(0.5)
+	arr.Number("1.50E1")
+	arr.BigInt(big.NewInt(-7))
+	arr.BigFloat(big.NewFloat(1e-7))
+	arr.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `[9223372036854776000,18446744073709552000,0.5,15,-7,1e-7]`, string(w.Bytes()))
+}
+
+func TestNonFiniteNumberPolicy(t *testing.T) {
+	writeNonFiniteValues := func(w *Writer) {
+		arr := w.Array()
+		arr.Float64(math.NaN())
+		arr.Float64(math.Inf(1))
+		arr.Float32(float32(math.Inf(-1)))
+		arr.BigFloat(new(big.Float).SetInf(false))
+		arr.Float64(1.5)
+		arr.End()
+	}
+
+	t.Run("error", func(t *testing.T) {
+		for _, write := range []func(*Writer){
+			func(w *Writer) { w.Float64(math.NaN()) },
+			func(w *Writer) { w.Float64(math.Inf(1)) },
+			func(w *Writer) { w.Float64(math.Inf(-1)) },
+			func(w *Writer) { w.Float32(float32(math.NaN())) },
+			func(w *Writer) { w.BigFloat(new(big.Float).SetInf(true)) },
+		} {
+			w := NewWriter()
+			write(&w)
+			require.Error(t, w.Error())
+			assert.IsType(t, UnsupportedValueError{}, w.Error())
+			assert.Len(t, w.Bytes(), 0)
+		}
+	})
+
+	t.Run("error set explicitly", func(t *testing.T) {
+		w := NewWriter()
+		w.SetNonFiniteNumberPolicy(NonFiniteNumberError)
+		writeNonFiniteValues(&w)
+		assert.Equal(t, UnsupportedValueError{Value: math.NaN()}.Error(), w.Error().Error())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		w := NewWriter()
+		w.SetNonFiniteNumberPolicy(NonFiniteNumberNull)
+		writeNonFiniteValues(&w)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `[null,null,null,null,1.5]`, string(w.Bytes()))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		w := NewWriter()
+		w.SetNonFiniteNumberPolicy(NonFiniteNumberString)
+		writeNonFiniteValues(&w)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `["NaN","Infinity","-Infinity","Infinity",1.5]`, string(w.Bytes()))
+	})
+
+	t.Run("canonical", func(t *testing.T) {
+		w := NewWriter()
+		w.SetCanonical()
+		w.SetNonFiniteNumberPolicy(NonFiniteNumberNull)
+		writeNonFiniteValues(&w)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `[null,null,null,null,1.5]`, string(w.Bytes()))
+	})
+}
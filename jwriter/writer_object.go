@@ -5,6 +5,7 @@ package jwriter
 type ObjectState struct {
 	w             *Writer
 	hasItems      bool
+	sorted        bool
 	previousState writerState
 }
 
@@ -17,14 +18,38 @@ func (obj *ObjectState) Name(name string) *Writer {
 	if obj.w == nil || obj.w.err != nil {
 		return &noOpWriter
 	}
-	if obj.hasItems {
-		if err := obj.w.tw.Delimiter(','); err != nil {
-			obj.w.AddError(err)
-			return obj.w
+	if obj.w.strict && !obj.w.checkName(obj.previousState.depth) {
+		return obj.w
+	}
+	if !obj.w.checkUTF8(name, true) {
+		return obj.w
+	}
+	if obj.w.names != nil && !obj.w.checkDuplicateName(name) {
+		return &noOpWriter
+	}
+	obj.w.state.needsValue = true
+	if obj.sorted {
+		obj.w.beginSortedMember(name)
+	} else {
+		if obj.hasItems {
+			if err := obj.w.tw.Delimiter(','); err != nil {
+				obj.w.AddError(err)
+				return obj.w
+			}
+		}
+		if obj.w.indent != nil {
+			obj.w.writeNewline(obj.w.state.depth)
 		}
 	}
 	obj.hasItems = true
-	obj.w.AddError(obj.w.tw.PropertyName(name))
+	if obj.w.encodesStrings() {
+		obj.w.writeEncodedPropertyName(name)
+	} else {
+		obj.w.AddError(obj.w.tw.PropertyName(name))
+	}
+	if obj.w.indent != nil {
+		obj.w.afterPropertyName()
+	}
 	return obj.w
 }
 
@@ -50,6 +75,18 @@ func (obj *ObjectState) End() {
 	if obj.w == nil || obj.w.err != nil {
 		return
 	}
+	if obj.w.strict && !obj.w.checkObjectEnd(obj.previousState.depth) {
+		return
+	}
+	if obj.sorted {
+		obj.w.endSortedObject()
+	}
+	if obj.w.names != nil {
+		obj.w.names.endObject()
+	}
+	if obj.w.indent != nil && obj.hasItems {
+		obj.w.writeNewline(obj.previousState.depth)
+	}
 	obj.w.AddError(obj.w.tw.Delimiter('}'))
 	obj.w.state = obj.previousState
 	obj.w = nil
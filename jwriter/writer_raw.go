@@ -0,0 +1,54 @@
+package jwriter
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// RawValuePolicy specifies how a Writer handles pre-encoded JSON data that is passed to Raw. See
+// Writer.SetRawValuePolicy.
+type RawValuePolicy int
+
+const (
+	// RawValuesUnchecked means that the data is written as-is, without checking whether it is
+	// valid JSON. This is the default.
+	RawValuesUnchecked RawValuePolicy = iota
+
+	// RawValuesValidated means that the data is parsed before it is written. If it is not a single
+	// well-formed JSON value, the Writer enters a failed state with the *json.SyntaxError that
+	// describes the problem, and nothing is written.
+	RawValuesValidated
+
+	// RawValuesCompacted is the same as RawValuesValidated, except that any whitespace outside of
+	// strings is also removed.
+	RawValuesCompacted
+)
+
+// SetRawValuePolicy specifies how the Writer should handle data that is passed to Raw. The default
+// is RawValuesUnchecked, so if the data was not valid JSON, the output will not be either; the
+// other policies protect against this at the cost of parsing the data.
+//
+// This has no effect in canonical mode (see SetCanonical), which always parses the data.
+//
+// This should be called before anything is written.
+func (w *Writer) SetRawValuePolicy(policy RawValuePolicy) {
+	w.encoding().raw = policy
+}
+
+// checkRaw is called if the policy is not RawValuesUnchecked. It returns the data to be written,
+// or nil if the data is invalid, in which case it has set the error state.
+//
+// This uses encoding/json's parser, rather than jreader, so that jwriter does not depend on jreader.
+// json.Compact also checks that the data is a single valid value.
+func (w *Writer) checkRaw(value json.RawMessage) json.RawMessage {
+	buf := bytes.NewBuffer(w.enc.scratch[:0])
+	if err := json.Compact(buf, value); err != nil {
+		w.AddError(err)
+		return nil
+	}
+	w.enc.scratch = buf.Bytes()
+	if w.enc.raw == RawValuesCompacted {
+		return w.enc.scratch
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRawInArray(policy RawValuePolicy, value json.RawMessage) *Writer {
+	w := NewWriter()
+	w.SetRawValuePolicy(policy)
+	arr := w.Array()
+	arr.Raw(value)
+	arr.End()
+	return &w
+}
+
+func TestRawValuePolicyWithValidData(t *testing.T) {
+	for _, p := range []struct {
+		input, validated, compacted string
+	}{
+		{`true`, `true`, `true`},
+		{` 1.50 `, ` 1.50 `, `1.50`},
+		{`"a b\" c"`, `"a b\" c"`, `"a b\" c"`},
+		{"[ 1,\n\t2 ]", "[ 1,\n\t2 ]", `[1,2]`},
+		{`{ "a b" : [ "\\", { } ] }`, `{ "a b" : [ "\\", { } ] }`, `{"a b":["\\",{}]}`},
+	} {
+		t.Run(p.input, func(t *testing.T) {
+			for _, policy := range []RawValuePolicy{RawValuesUnchecked, RawValuesValidated} {
+				w := writeRawInArray(policy, json.RawMessage(p.input))
+				require.NoError(t, w.Error())
+				assert.Equal(t, "["+p.validated+"]", string(w.Bytes()))
+			}
+			w := writeRawInArray(RawValuesCompacted, json.RawMessage(p.input))
+			require.NoError(t, w.Error())
+			assert.Equal(t, "["+p.compacted+"]", string(w.Bytes()))
+		})
+	}
+}
+
+func TestRawValuePolicyWithInvalidData(t *testing.T) {
+	for _, input := range []string{``, ` `, `"abc`, `{"a":`, `1 2`, `[] {}`, `nul`} {
+		t.Run(input, func(t *testing.T) {
+			for _, policy := range []RawValuePolicy{RawValuesValidated, RawValuesCompacted} {
+				w := writeRawInArray(policy, json.RawMessage(input))
+				assert.Error(t, w.Error())
+			}
+		})
+	}
+}
+
+func TestRawValuePolicyReportsSyntaxErrorOffset(t *testing.T) {
+	w := writeRawInArray(RawValuesValidated, json.RawMessage(`1 2`))
+	require.IsType(t, &json.SyntaxError{}, w.Error())
+	assert.Equal(t, int64(3), w.Error().(*json.SyntaxError).Offset)
+}
+
+func TestRawValuesUncheckedWritesInvalidData(t *testing.T) {
+	w := writeRawInArray(RawValuesUnchecked, json.RawMessage(`{"a":`))
+	require.NoError(t, w.Error())
+	assert.Equal(t, `[{"a":]`, string(w.Bytes()))
+}
+
+func TestRawValuesCompactedWithIndent(t *testing.T) {
+	w := NewWriter()
+	w.SetIndent("", "  ")
+	w.SetRawValuePolicy(RawValuesCompacted)
+	obj := w.Object()
+	obj.Name("a").Raw(json.RawMessage("[ 1,\n 2 ]"))
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, "{\n  \"a\": [\n    1,\n    2\n  ]\n}", string(w.Bytes()))
+}
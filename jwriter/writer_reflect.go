@@ -0,0 +1,350 @@
+package jwriter
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/launchdarkly/go-jsonstream/v3/internal/ordered"
+	"github.com/launchdarkly/go-jsonstream/v3/internal/structinfo"
+)
+
+//nolint:gochecknoglobals
+var (
+	writableType      = reflect.TypeOf((*Writable)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+	orderedObjectType = reflect.TypeOf(ordered.Object(nil))
+)
+
+// WriteValue writes an arbitrary Go value as JSON, using reflection to determine how the value's
+// type should be represented.
+//
+// This is meant to be used for types that do not have a hand-written or generated implementation
+// of Writable, so that they can be written within the same Writer, and with the same error
+// reporting, as the rest of the data. It is considerably slower than a Writable implementation. The
+// rules are similar to those of encoding/json:
+//
+// - If the value implements Writable, its WriteToJSONWriter method is used. Otherwise, if it
+// implements json.Marshaler, the output of MarshalJSON is written as-is; if it implements
+// encoding.TextMarshaler, the output of MarshalText is written as a JSON string.
+//
+// - Booleans, numbers, and strings are written as the corresponding JSON types. A json.Number is
+// written as a JSON number. A jreader.OrderedObject is written as a JSON object, as by Writer.Value.
+//
+// - Slices and arrays are written as JSON arrays, except that a []byte is written as a
+// base64-encoded string. Maps whose keys are strings or integers are written as JSON objects, with
+// the properties sorted by name. A nil pointer, slice, map, or interface is written as a null.
+//
+// - Structs are written as JSON objects, using the same "json" struct tags as encoding/json,
+// including the "omitempty" and "string" options. Fields of embedded structs are promoted.
+//
+// If a value contains a type that cannot be represented in JSON (such as a channel or a function),
+// the Writer enters a failed state with an UnsupportedTypeError. If a value refers to itself, so
+// that it could never be completely written, the Writer enters a failed state with a CycleError.
+// Errors returned by MarshalJSON or MarshalText also put the Writer into a failed state.
+func WriteValue(w *Writer, value interface{}) {
+	if value == nil {
+		w.Null()
+		return
+	}
+	w.writeReflectValue(reflect.ValueOf(value))
+}
+
+func (w *Writer) writeReflectValue(v reflect.Value) {
+	if w.err != nil {
+		return
+	}
+	t := v.Type()
+	switch t.Kind() { //nolint:exhaustive // other kinds are handled below
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			w.Null()
+			return
+		}
+	}
+	if v.CanAddr() && t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(writableType) {
+		v = v.Addr()
+		t = v.Type()
+	}
+	if t.Implements(writableType) {
+		v.Interface().(Writable).WriteToJSONWriter(w)
+		return
+	}
+	if t.Implements(jsonMarshalerType) {
+		data, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			w.AddError(err)
+			return
+		}
+		w.Raw(data)
+		return
+	}
+	if t.Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			w.AddError(err)
+			return
+		}
+		w.String(string(text))
+		return
+	}
+	if t == orderedObjectType {
+		w.writeOrderedObject(v.Interface().(ordered.Object))
+		return
+	}
+	switch t.Kind() { //nolint:exhaustive // all other kinds are unsupported
+	case reflect.Bool:
+		w.Bool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.Int64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.Uint64(v.Uint())
+	case reflect.Float32:
+		w.Float32(float32(v.Float()))
+	case reflect.Float64:
+		w.Float64(v.Float())
+	case reflect.String:
+		if t == jsonNumberType {
+			w.Number(json.Number(v.String()))
+		} else {
+			w.String(v.String())
+		}
+	case reflect.Ptr:
+		if w.enterReference(v) {
+			w.writeReflectValue(v.Elem())
+			w.leaveReference(v)
+		}
+	case reflect.Interface:
+		w.writeReflectValue(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			w.Null()
+		} else if t.Elem().Kind() == reflect.Uint8 && !t.Elem().Implements(writableType) {
+			w.String(base64.StdEncoding.EncodeToString(v.Bytes()))
+		} else if w.enterReference(v) {
+			w.writeReflectArray(v)
+			w.leaveReference(v)
+		}
+	case reflect.Array:
+		w.writeReflectArray(v)
+	case reflect.Map:
+		if v.IsNil() {
+			w.Null()
+		} else if w.enterReference(v) {
+			w.writeReflectMap(v)
+			w.leaveReference(v)
+		}
+	case reflect.Struct:
+		w.writeReflectStruct(v)
+	default:
+		w.AddError(UnsupportedTypeError{Type: t})
+	}
+}
+
+// startDetectingCyclesAfter is the number of nested pointers, maps, and slices that WriteValue will
+// enter before it starts checking whether it has already seen them. This is the same as in
+// encoding/json; it avoids the overhead of the check for data that is not deeply nested, which is
+// very unlikely to be cyclic.
+const startDetectingCyclesAfter = 1000
+
+// references keeps track of the pointers, maps, and slices that WriteValue is currently writing.
+type references struct {
+	depth int
+	seen  map[referenceKey]struct{}
+}
+
+type referenceKey struct {
+	ptr    uintptr
+	length int // for a slice, since a slice of the same array with a different length is not a cycle
+	typ    reflect.Type
+}
+
+// enterReference is called before writing the contents of a non-nil pointer, map, or slice. It
+// returns false if the same value is already being written, in which case it has set the error
+// state.
+func (w *Writer) enterReference(v reflect.Value) bool {
+	w.refs.depth++
+	if w.refs.depth <= startDetectingCyclesAfter {
+		return true
+	}
+	key := newReferenceKey(v)
+	if _, ok := w.refs.seen[key]; ok {
+		w.refs.depth--
+		w.AddError(CycleError{Type: v.Type()})
+		return false
+	}
+	if w.refs.seen == nil {
+		w.refs.seen = make(map[referenceKey]struct{})
+	}
+	w.refs.seen[key] = struct{}{}
+	return true
+}
+
+// leaveReference is called after writing a value for which enterReference returned true.
+func (w *Writer) leaveReference(v reflect.Value) {
+	if w.refs.depth > startDetectingCyclesAfter {
+		delete(w.refs.seen, newReferenceKey(v))
+	}
+	w.refs.depth--
+}
+
+func newReferenceKey(v reflect.Value) referenceKey {
+	key := referenceKey{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.length = v.Len()
+	}
+	return key
+}
+
+func (w *Writer) writeReflectArray(v reflect.Value) {
+	arr := w.Array()
+	for i := 0; i < v.Len() && w.err == nil; i++ {
+		w.writeReflectValue(v.Index(i))
+	}
+	arr.End()
+}
+
+func (w *Writer) writeReflectMap(v reflect.Value) {
+	keyType := v.Type().Key()
+	keyIsText := keyType.Implements(textMarshalerType)
+	if !keyIsText {
+		switch keyType.Kind() { //nolint:exhaustive // all other kinds are unsupported
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			w.AddError(UnsupportedTypeError{Type: v.Type()})
+			return
+		}
+	}
+	names := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		var name string
+		key := iter.Key()
+		switch {
+		case keyIsText:
+			text, err := key.Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				w.AddError(err)
+				return
+			}
+			name = string(text)
+		case key.Kind() == reflect.String:
+			name = key.String()
+		case key.CanInt():
+			name = strconv.FormatInt(key.Int(), 10)
+		default:
+			name = strconv.FormatUint(key.Uint(), 10)
+		}
+		names = append(names, name)
+		values[name] = iter.Value()
+	}
+	sortStrings(names)
+	obj := w.Object()
+	for _, name := range names {
+		if w.err != nil {
+			break
+		}
+		obj.Name(name).writeReflectValue(values[name])
+	}
+	obj.End()
+}
+
+func (w *Writer) writeReflectStruct(v reflect.Value) {
+	info := structinfo.ForType(v.Type())
+	obj := w.Object()
+	for i := range info.Fields {
+		if w.err != nil {
+			break
+		}
+		field := &info.Fields[i]
+		fv, ok := fieldForReading(v, field.Index)
+		if !ok || (field.OmitEmpty && structinfo.IsEmptyValue(fv)) {
+			continue
+		}
+		if field.AsString {
+			obj.Name(field.Name).writeReflectQuotedValue(fv)
+		} else {
+			obj.Name(field.Name).writeReflectValue(fv)
+		}
+	}
+	obj.End()
+}
+
+// fieldForReading returns the struct field with the specified index sequence. It returns false if
+// the field is within an embedded struct pointer that is nil.
+func fieldForReading(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIndex)
+	}
+	return v, true
+}
+
+// writeReflectQuotedValue writes a field that has the "string" option, meaning that a boolean or
+// numeric value is encoded as a JSON string.
+func (w *Writer) writeReflectQuotedValue(v reflect.Value) {
+	switch v.Kind() { //nolint:exhaustive // structinfo only sets AsString for these kinds
+	case reflect.Bool:
+		w.String(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.String(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.String(strconv.FormatUint(v.Uint(), 10))
+	default:
+		w.String(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	}
+}
+
+// isValidNumber returns true if s is a valid JSON number literal.
+func isValidNumber(s string) bool {
+	if s != "" && s[0] == '-' {
+		s = s[1:]
+	}
+	if s == "" {
+		return false
+	}
+	switch {
+	case s[0] == '0':
+		s = s[1:]
+	case s[0] >= '1' && s[0] <= '9':
+		s = skipDigits(s[1:])
+	default:
+		return false
+	}
+	if len(s) >= 2 && s[0] == '.' && isDigit(s[1]) {
+		s = skipDigits(s[2:])
+	}
+	if len(s) >= 2 && (s[0] == 'e' || s[0] == 'E') {
+		s = s[1:]
+		if s[0] == '+' || s[0] == '-' {
+			s = s[1:]
+		}
+		if s == "" || !isDigit(s[0]) {
+			return false
+		}
+		s = skipDigits(s)
+	}
+	return s == ""
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func skipDigits(s string) string {
+	for s != "" && isDigit(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
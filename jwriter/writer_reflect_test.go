@@ -0,0 +1,206 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reflectEmbedded struct {
+	Inner  string `json:"inner"`
+	Hidden string `json:"outer"` // hidden by the field of the same name in the outer struct
+}
+
+type reflectStruct struct {
+	reflectEmbedded
+	Outer    string            `json:"outer"`
+	Bool     bool              `json:"bool"`
+	Int      int               `json:"int"`
+	Uint64   uint64            `json:"uint64"`
+	Float32  float32           `json:"float32"`
+	Quoted   int64             `json:"quoted,string"`
+	Ptr      *string           `json:"ptr"`
+	NilPtr   *string           `json:"nilPtr"`
+	Omitted  *string           `json:"omitted,omitempty"`
+	Slice    []int             `json:"slice"`
+	NilSlice []int             `json:"nilSlice"`
+	Array    [2]int            `json:"array"`
+	Bytes    []byte            `json:"bytes"`
+	Map      map[string]bool   `json:"map"`
+	IntMap   map[int]string    `json:"intMap"`
+	Any      interface{}       `json:"any"`
+	Item     writableItem      `json:"item"`
+	Time     time.Time         `json:"time"`
+	Raw      json.RawMessage   `json:"raw"`
+	Number   json.Number       `json:"number"`
+	Untagged string            //nolint:tagliatelle
+	Ignored  string            `json:"-"`
+	Items    map[string]*int64 `json:"items"`
+	private  string
+}
+
+func TestWriteValue(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		c, five := "c", int64(5)
+		value := reflectStruct{
+			reflectEmbedded: reflectEmbedded{Inner: "a", Hidden: "z"},
+			Outer:           "b",
+			Bool:            true,
+			Int:             -3,
+			Uint64:          1 << 63,
+			Float32:         1.5,
+			Quoted:          123,
+			Ptr:             &c,
+			Slice:           []int{1, 2},
+			Array:           [2]int{3, 4},
+			Bytes:           []byte("hi"),
+			Map:             map[string]bool{"y": false, "x": true},
+			IntMap:          map[int]string{10: "ten", 2: "two"},
+			Any:             []interface{}{1, "two", map[string]interface{}{"three": nil}},
+			Item:            writableItem{key: "k", count: 4},
+			Time:            time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Raw:             json.RawMessage(`[true]`),
+			Number:          json.Number("1.5e3"),
+			Untagged:        "d",
+			Ignored:         "e",
+			Items:           map[string]*int64{"i": &five, "j": nil},
+			private:         "f",
+		}
+		w := NewWriter()
+		WriteValue(&w, value)
+		require.NoError(t, w.Error())
+		assert.JSONEq(t, `{
+			"inner": "a", "outer": "b", "bool": true, "int": -3, "uint64": 9223372036854775808,
+			"float32": 1.5, "quoted": "123", "ptr": "c", "nilPtr": null, "slice": [1, 2],
+			"nilSlice": null, "array": [3, 4], "bytes": "aGk=", "map": {"x": true, "y": false},
+			"intMap": {"10": "ten", "2": "two"}, "any": [1, "two", {"three": null}],
+			"item": {"key": "k", "count": 4}, "time": "2020-01-02T03:04:05Z", "raw": [true],
+			"number": 1.5e3, "Untagged": "d", "items": {"i": 5, "j": null}
+		}`, string(w.Bytes()))
+	})
+
+	t.Run("map keys are sorted", func(t *testing.T) {
+		w := NewWriter()
+		WriteValue(&w, map[string]int{"c": 3, "a": 1, "b": 2})
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"a":1,"b":2,"c":3}`, string(w.Bytes()))
+	})
+
+	t.Run("pointer to writable", func(t *testing.T) {
+		w := NewWriter()
+		WriteValue(&w, &writableItem{key: "k", count: 1})
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"key":"k","count":1}`, string(w.Bytes()))
+	})
+
+	t.Run("nil embedded pointer", func(t *testing.T) {
+		value := struct {
+			*reflectEmbedded
+			Other int
+		}{Other: 1}
+		w := NewWriter()
+		WriteValue(&w, value)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"Other":1}`, string(w.Bytes()))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		w := NewWriter()
+		WriteValue(&w, nil)
+		require.NoError(t, w.Error())
+		assert.Equal(t, `null`, string(w.Bytes()))
+	})
+
+	t.Run("invalid json.Number", func(t *testing.T) {
+		w := NewWriter()
+		WriteValue(&w, json.Number("0x10"))
+		assert.Error(t, w.Error())
+	})
+
+	t.Run("marshaler error", func(t *testing.T) {
+		w := NewWriter()
+		WriteValue(&w, failingMarshaler{})
+		assert.Equal(t, errMarshalFailed, w.Error())
+	})
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, value := range []interface{}{
+			make(chan int),
+			func() {},
+			map[float64]int{1: 1},
+			[]interface{}{complex(1, 2)},
+		} {
+			w := NewWriter()
+			WriteValue(&w, value)
+			require.Error(t, w.Error())
+			assert.IsType(t, UnsupportedTypeError{}, w.Error())
+		}
+	})
+}
+
+type reflectListNode struct {
+	Value int              `json:"value"`
+	Next  *reflectListNode `json:"next,omitempty"`
+}
+
+func TestWriteValueCycles(t *testing.T) {
+	t.Run("pointer cycle", func(t *testing.T) {
+		node := &reflectListNode{}
+		node.Next = &reflectListNode{Value: 1, Next: node}
+		w := NewWriter()
+		WriteValue(&w, node)
+		assert.Equal(t, CycleError{Type: reflect.TypeOf(node)}, w.Error())
+	})
+
+	t.Run("map cycle", func(t *testing.T) {
+		m := map[string]interface{}{}
+		m["m"] = m
+		w := NewWriter()
+		WriteValue(&w, struct{ M map[string]interface{} }{m})
+		assert.Equal(t, CycleError{Type: reflect.TypeOf(m)}, w.Error())
+	})
+
+	t.Run("slice cycle", func(t *testing.T) {
+		s := []interface{}{nil}
+		s[0] = s
+		w := NewWriter()
+		WriteValue(&w, &s)
+		assert.Equal(t, CycleError{Type: reflect.TypeOf(s)}, w.Error())
+	})
+
+	t.Run("deeply nested value that is not cyclic", func(t *testing.T) {
+		var head *reflectListNode
+		for i := 0; i < startDetectingCyclesAfter*2; i++ {
+			head = &reflectListNode{Value: i, Next: head}
+		}
+		shared := &reflectListNode{Value: -1}
+		w := NewWriter()
+		WriteValue(&w, []*reflectListNode{head, shared, shared})
+		require.NoError(t, w.Error())
+		expectedEnd := `{"value":0}` + strings.Repeat("}", startDetectingCyclesAfter*2-1) + `,{"value":-1},{"value":-1}]`
+		assert.True(t, strings.HasSuffix(string(w.Bytes()), expectedEnd))
+	})
+}
+
+var errMarshalFailed = errors.New("sorry")
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errMarshalFailed
+}
+
+func TestIsValidNumber(t *testing.T) {
+	for _, s := range []string{"0", "-0", "1", "-12", "1.5", "0.25", "1e3", "1E+3", "-1.5e-3"} {
+		assert.True(t, isValidNumber(s), s)
+	}
+	for _, s := range []string{"", "-", "01", "1.", ".5", "1e", "1e+", "+1", "0x10", "Inf", "1.5.5"} {
+		assert.False(t, isValidNumber(s), s)
+	}
+}
@@ -0,0 +1,126 @@
+package jwriter
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// SetSortKeys causes the Writer to write the members of every object in order of their property
+// names, regardless of the order in which they are written with ObjectState.Name. This is useful
+// for producing output that is easy to compare, such as configuration files or test snapshots.
+//
+// Names are compared as sequences of UTF-16 code units, as in RFC 8785. This is the same as
+// ordinary string ordering unless the names contain characters outside of the Basic Multilingual
+// Plane. If two members have the same name, they are written in the order they were received.
+//
+// Since the members of an object cannot be written until all of them are known, the Writer buffers
+// each object in memory from its beginning until ObjectState.End is called, including any arrays
+// and objects nested within it; the buffered data is about the same size as the object's encoded
+// JSON. With NewStreamingWriter, output is still written to the io.Writer as usual whenever no
+// object is in progress, so for instance a large top-level array of small objects requires only
+// enough memory for one object at a time; but a top-level object is buffered in its entirety.
+//
+// Arrays and objects written with Raw are parsed and written again in the same way as all other
+// output, so that any objects within them are also sorted (and indented, if SetIndent is used);
+// if such a value is not valid JSON, the Writer enters a failed state. Numbers within them keep
+// their original text.
+//
+// This works with SetIndent and SetIndentOptions. SetCanonical also sorts the members of objects.
+//
+// This should be called before anything is written.
+func (w *Writer) SetSortKeys() {
+	w.encoding().sortKeys = true
+}
+
+// sortedObject holds the state of an object whose members are being written in sorted order. Since
+// the members are not written in the order we receive them, each one is written to a temporary
+// tokenWriter and we remember where it starts; the real tokenWriter is saved until the object ends.
+type sortedObject struct {
+	saved   tokenWriter
+	members []sortedMember
+}
+
+type sortedMember struct {
+	name       string
+	start, end int
+}
+
+// beginSortedObject is called after writing the opening delimiter of an object whose members are
+// to be sorted.
+func (w *Writer) beginSortedObject() {
+	if len(w.sorting) < cap(w.sorting) {
+		w.sorting = w.sorting[:len(w.sorting)+1]
+	} else {
+		w.sorting = append(w.sorting, sortedObject{})
+	}
+	obj := &w.sorting[len(w.sorting)-1]
+	obj.saved = w.tw
+	obj.members = obj.members[:0]
+	w.tw = w.tw.newTemporary()
+}
+
+// beginSortedMember is called instead of writing a comma before an object property name, if the
+// object is being sorted.
+func (w *Writer) beginSortedMember(name string) {
+	obj := &w.sorting[len(w.sorting)-1]
+	obj.members = append(obj.members, sortedMember{name: name, start: w.tw.Size()})
+}
+
+// endSortedObject writes the buffered members of the current object to the real tokenWriter in
+// sorted order, with commas (and newlines, if indenting) between them. The caller still needs to
+// write the closing delimiter.
+func (w *Writer) endSortedObject() {
+	obj := &w.sorting[len(w.sorting)-1]
+	buffered := w.tw.Bytes()
+	w.tw = obj.saved
+	obj.saved = tokenWriter{}
+	w.sorting = w.sorting[:len(w.sorting)-1]
+
+	members := obj.members
+	for i := range members {
+		if i+1 < len(members) {
+			members[i].end = members[i+1].start
+		} else {
+			members[i].end = len(buffered)
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		return lessUTF16(members[i].name, members[j].name)
+	})
+	for i, m := range members {
+		if i > 0 {
+			w.AddError(w.tw.Delimiter(','))
+		}
+		if w.indent != nil {
+			w.writeNewline(w.state.depth)
+		}
+		w.AddError(w.tw.Raw(buffered[m.start:m.end]))
+	}
+}
+
+// lessUTF16 compares two strings as sequences of UTF-16 code units, as RFC 8785 requires for
+// sorting property names. This is the same as comparing code points, except that characters
+// outside of the Basic Multilingual Plane, which are represented as surrogate pairs in UTF-16, sort
+// before characters in the range U+E000 to U+FFFF.
+func lessUTF16(a, b string) bool {
+	for a != "" && b != "" {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			ua, ub := firstUTF16Unit(ra), firstUTF16Unit(rb)
+			if ua != ub {
+				return ua < ub
+			}
+			return ra < rb
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return a == "" && b != ""
+}
+
+func firstUTF16Unit(r rune) rune {
+	if r >= 0x10000 {
+		return 0xd800 + (r-0x10000)>>10
+	}
+	return r
+}
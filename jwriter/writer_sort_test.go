@@ -0,0 +1,118 @@
+package jwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortKeys(t *testing.T) {
+	w := NewWriter()
+	w.SetSortKeys()
+	obj := w.Object()
+	obj.Name("c").Int(1)
+	arr := obj.Name("a").Array()
+	nested := arr.Object()
+	nested.Name("z").Bool(true)
+	nested.Name("y").Null()
+	nested.End()
+	arr.Float64(1.5)
+	arr.End()
+	empty := obj.Name("b").Object()
+	empty.End()
+	obj.Name("é").String("x")
+	obj.Name("B").String("y")
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"B":"y","a":[{"y":null,"z":true},1.5],"b":{},"c":1,"é":"x"}`, string(w.Bytes()))
+}
+
+func TestSortKeysKeepsOrderOfDuplicateNames(t *testing.T) {
+	w := NewWriter()
+	w.SetSortKeys()
+	obj := w.Object()
+	obj.Name("b").Int(1)
+	obj.Name("a").Int(2)
+	obj.Name("b").Int(3)
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":2,"b":1,"b":3}`, string(w.Bytes()))
+}
+
+func TestSortKeysWithIndent(t *testing.T) {
+	w := NewWriter()
+	w.SetIndentOptions(IndentOptions{Indent: "  ", SpaceAfterColon: true, MaxCompactArrayLength: 3})
+	w.SetSortKeys()
+	obj := w.Object()
+	arr := obj.Name("b").Array()
+	arr.Int(1)
+	arr.Int(2)
+	arr.End()
+	nested := obj.Name("a").Object()
+	nested.Name("y").Int(3)
+	nested.Name("x").Int(4)
+	nested.End()
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{
+  "a": {
+    "x": 4,
+    "y": 3
+  },
+  "b": [1, 2]
+}`, string(w.Bytes()))
+}
+
+func TestSortKeysWithRawValues(t *testing.T) {
+	w := NewWriter()
+	w.SetSortKeys()
+	obj := w.Object()
+	obj.Name("b").Raw(json.RawMessage(` { "y": [ 1.50, {"d": 1, "c": 2} ], "x": "\u0041" } `))
+	obj.Name("a").Raw(json.RawMessage(`2.0`))
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a":2.0,"b":{"x":"A","y":[1.50,{"c":2,"d":1}]}}`, string(w.Bytes()))
+}
+
+func TestSortKeysWithRawValuesAndIndent(t *testing.T) {
+	w := NewWriter()
+	w.SetIndent("", "  ")
+	w.SetSortKeys()
+	arr := w.Array()
+	arr.Raw(json.RawMessage(`{"b":1,"a":[]}`))
+	arr.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, "[\n  {\n    \"a\": [],\n    \"b\": 1\n  }\n]", string(w.Bytes()))
+}
+
+func TestSortKeysWithInvalidRawValue(t *testing.T) {
+	for _, value := range []string{`{"a":`, `[1 x]`, `{} {}`} {
+		t.Run(value, func(t *testing.T) {
+			w := NewWriter()
+			w.SetSortKeys()
+			w.Raw(json.RawMessage(value))
+			assert.Error(t, w.Error())
+		})
+	}
+}
+
+func TestSortKeysWithStreamingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewStreamingWriter(&buf, 10)
+	w.SetSortKeys()
+	arr := w.Array()
+	for i := 0; i < 3; i++ {
+		obj := arr.Object()
+		obj.Name("value").Int(i)
+		obj.Name("key").String("abcdefghij")
+		obj.End()
+	}
+	arr.End()
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.Error())
+	assert.Equal(t, `[{"key":"abcdefghij","value":0},{"key":"abcdefghij","value":1},`+
+		`{"key":"abcdefghij","value":2}]`, buf.String())
+}
@@ -0,0 +1,108 @@
+package jwriter
+
+import "fmt"
+
+const (
+	errMsgValueWithoutName   = "value written in an object without a property name"
+	errMsgNameWithoutValue   = "property name written without a value for the previous property"
+	errMsgEndWithoutValue    = "object ended after a property name without a value"
+	errMsgMultipleValues     = "more than one value written at the top level"
+	errMsgNotInnermost       = "%s used while a nested array or object is still open"
+	errMsgNoValue            = "no value was written"
+	errMsgUnclosedContainers = "%d array(s) or object(s) not closed"
+)
+
+// SetStrict causes the Writer to check that the sequence of method calls produces well-formed
+// JSON. Normally, the Writer trusts the caller to use it correctly, so for instance writing a
+// value in an object without calling Name first produces invalid output without any error. In
+// strict mode, the Writer instead enters a failed state with a StructureError if:
+//
+// - a value is written in an object without a property name, or Name is called twice without a
+// value in between, or the object ends right after a property name;
+//
+// - more than one value is written at the top level;
+//
+// - an ArrayState or ObjectState is used while an array or object nested within it is still open;
+//
+// - Bytes or Flush is called while an array or object is still open.
+//
+// This adds a small amount of overhead to each operation, so it is mainly intended for testing
+// and debugging. Regardless of this setting, Finish can be used to verify that a document is
+// complete.
+//
+// This should be called before anything is written.
+func (w *Writer) SetStrict() {
+	w.strict = true
+}
+
+// Finish verifies that the Writer has produced a complete JSON document: a value was written at
+// the top level, and every array and object has been closed. If not, the Writer enters a failed
+// state with a StructureError. Then, if this is a streaming writer created with NewStreamingWriter,
+// it flushes any remaining output.
+//
+// The return value is the Writer's error, if any.
+func (w *Writer) Finish() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.state.depth > 0 {
+		w.AddError(StructureError{Message: fmt.Sprintf(errMsgUnclosedContainers, w.state.depth)})
+		return w.err
+	}
+	if !w.state.hasValue {
+		w.AddError(StructureError{Message: errMsgNoValue})
+		return w.err
+	}
+	w.AddError(w.output().Flush())
+	return w.err
+}
+
+// checkValueAllowed is called in strict mode before writing any value.
+func (w *Writer) checkValueAllowed() bool {
+	switch {
+	case w.state.depth == 0 && w.state.hasValue:
+		w.AddError(StructureError{Message: errMsgMultipleValues})
+	case w.state.depth > 0 && !w.state.inArray && !w.state.needsValue:
+		w.AddError(StructureError{Message: errMsgValueWithoutName})
+	}
+	return w.err == nil
+}
+
+// checkInnermost is called in strict mode before an ArrayState or ObjectState writes a delimiter or
+// property name itself, to verify that it is the innermost array or object that is open. The depth is that of the
+// ArrayState or ObjectState's parent.
+func (w *Writer) checkInnermost(parentDepth int, operation string) bool {
+	if w.state.depth != parentDepth+1 {
+		w.AddError(StructureError{Message: fmt.Sprintf(errMsgNotInnermost, operation)})
+	}
+	return w.err == nil
+}
+
+// checkName is called in strict mode before writing a property name.
+func (w *Writer) checkName(parentDepth int) bool {
+	if !w.checkInnermost(parentDepth, "ObjectState.Name") {
+		return false
+	}
+	if w.state.needsValue {
+		w.AddError(StructureError{Message: errMsgNameWithoutValue})
+	}
+	return w.err == nil
+}
+
+// checkObjectEnd is called in strict mode before ending an object.
+func (w *Writer) checkObjectEnd(parentDepth int) bool {
+	if !w.checkInnermost(parentDepth, "ObjectState.End") {
+		return false
+	}
+	if w.state.needsValue {
+		w.AddError(StructureError{Message: errMsgEndWithoutValue})
+	}
+	return w.err == nil
+}
+
+// checkComplete is called in strict mode before returning or flushing the output.
+func (w *Writer) checkComplete() {
+	if w.state.depth > 0 {
+		w.AddError(StructureError{Message: fmt.Sprintf(errMsgUnclosedContainers, w.state.depth)})
+	}
+}
@@ -0,0 +1,151 @@
+package jwriter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictModeAllowsWellFormedOutput(t *testing.T) {
+	w := NewWriter()
+	w.SetStrict()
+	obj := w.Object()
+	obj.Name("a").Int(1)
+	arr := obj.Name("b").Array()
+	arr.Bool(true)
+	nested := arr.Object()
+	nested.End()
+	arr.End()
+	obj.Name("c").Null()
+	obj.End()
+	require.NoError(t, w.Finish())
+	assert.Equal(t, `{"a":1,"b":[true,{}],"c":null}`, string(w.Bytes()))
+}
+
+func TestStrictModeErrors(t *testing.T) {
+	for _, p := range []struct {
+		name    string
+		write   func(*Writer)
+		message string
+	}{
+		{"value without name", func(w *Writer) {
+			obj := w.Object()
+			w.Int(1)
+			obj.End()
+		}, errMsgValueWithoutName},
+		{"value after property value", func(w *Writer) {
+			obj := w.Object()
+			obj.Name("a").Int(1)
+			w.Int(2)
+			obj.End()
+		}, errMsgValueWithoutName},
+		{"name twice", func(w *Writer) {
+			obj := w.Object()
+			obj.Name("a")
+			obj.Name("b").Int(1)
+			obj.End()
+		}, errMsgNameWithoutValue},
+		{"end after name", func(w *Writer) {
+			obj := w.Object()
+			obj.Name("a")
+			obj.End()
+		}, errMsgEndWithoutValue},
+		{"two top-level values", func(w *Writer) {
+			w.Int(1)
+			w.Int(2)
+		}, errMsgMultipleValues},
+		{"top-level value after array", func(w *Writer) {
+			arr := w.Array()
+			arr.End()
+			w.String("x")
+		}, errMsgMultipleValues},
+		{"name in outer object", func(w *Writer) {
+			obj := w.Object()
+			arr := obj.Name("a").Array()
+			obj.Name("b").Int(1)
+			arr.End()
+			obj.End()
+		}, "ObjectState.Name used while a nested array or object is still open"},
+		{"end outer array", func(w *Writer) {
+			arr := w.Array()
+			_ = arr.Array()
+			arr.End()
+		}, "ArrayState.End used while a nested array or object is still open"},
+		{"end outer object", func(w *Writer) {
+			obj := w.Object()
+			_ = obj.Name("a").Object()
+			obj.End()
+		}, "ObjectState.End used while a nested array or object is still open"},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			w := NewWriter()
+			w.SetStrict()
+			p.write(&w)
+			assert.Equal(t, StructureError{Message: p.message}, w.Error())
+		})
+	}
+}
+
+func TestStrictModeChecksForUnclosedContainers(t *testing.T) {
+	t.Run("Bytes", func(t *testing.T) {
+		w := NewWriter()
+		w.SetStrict()
+		arr := w.Array()
+		_ = arr.Object()
+		_ = w.Bytes()
+		assert.Equal(t, StructureError{Message: "2 array(s) or object(s) not closed"}, w.Error())
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewStreamingWriter(&buf, 100)
+		w.SetStrict()
+		_ = w.Array()
+		err := w.Flush()
+		assert.Equal(t, StructureError{Message: "1 array(s) or object(s) not closed"}, err)
+		assert.Equal(t, err, w.Error())
+	})
+}
+
+func TestNonStrictModeDoesNotCheckStructure(t *testing.T) {
+	w := NewWriter()
+	w.Int(1)
+	w.Int(2)
+	assert.NoError(t, w.Error())
+}
+
+func TestFinish(t *testing.T) {
+	t.Run("complete", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewStreamingWriter(&buf, 100)
+		obj := w.Object()
+		obj.Name("a").Bool(true)
+		obj.End()
+		require.NoError(t, w.Finish())
+		assert.Equal(t, `{"a":true}`, buf.String())
+	})
+
+	t.Run("no value", func(t *testing.T) {
+		w := NewWriter()
+		assert.Equal(t, StructureError{Message: errMsgNoValue}, w.Finish())
+	})
+
+	t.Run("unclosed", func(t *testing.T) {
+		w := NewWriter()
+		obj := w.Object()
+		_ = obj.Name("a").Array()
+		assert.Equal(t, StructureError{Message: "2 array(s) or object(s) not closed"}, w.Finish())
+	})
+
+	t.Run("previous error", func(t *testing.T) {
+		w := NewWriter()
+		w.AddError(UnsupportedValueError{Value: 1})
+		assert.Equal(t, UnsupportedValueError{Value: 1}, w.Finish())
+	})
+}
+
+func TestStructureErrorMessage(t *testing.T) {
+	assert.Equal(t, "invalid JSON structure: no value was written", StructureError{Message: errMsgNoValue}.Error())
+}
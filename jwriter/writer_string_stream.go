@@ -0,0 +1,158 @@
+package jwriter
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+var errStringStreamClosed = errors.New( //nolint:gochecknoglobals
+	"cannot write to a string stream after it has been closed")
+
+// stringStream is the io.WriteCloser returned by Writer.StringStream.
+type stringStream struct {
+	w        *Writer
+	escaping StringEscaping
+	closed   bool
+
+	// If the last Write ended in the middle of a multi-byte character, pending holds its first bytes.
+	pending  [utf8.UTFMax]byte
+	nPending int
+
+	// offset is the number of bytes of the string that have been written so far.
+	offset int
+	buf    []byte
+}
+
+// StringStream begins writing a JSON string value to the output, and returns an io.WriteCloser for
+// writing the content of the string in any number of pieces. Each piece is escaped and written to
+// the output immediately, so a large string never has to be held in memory all at once. Calling
+// Close writes the closing quote.
+//
+// Characters are escaped as they would be by String, and invalid UTF-8 is handled as described in
+// SetInvalidUTF8Policy, even if a multi-byte character is split across two calls to Write. Byte
+// offsets in an InvalidUTF8Error are relative to the beginning of the string.
+//
+// No other Writer, ArrayState, or ObjectState methods should be called until the stream has been
+// closed. If the Writer is in a failed state, Write and Close return its error. If it is the stub
+// Writer that ObjectState.Maybe returns for a property that is not written, or that is returned
+// for a dropped duplicate property name, the stream discards the content without an error.
+func (w *Writer) StringStream() io.WriteCloser {
+	if w == &noOpWriter {
+		return discardingStringStream{}
+	}
+	s := &stringStream{w: w}
+	if !w.beforeValue() {
+		return s
+	}
+	if w.encodesStrings() {
+		s.escaping = w.enc.escaping
+	} else {
+		s.escaping = w.tw.stringEscaping()
+	}
+	w.AddError(w.tw.Delimiter('"'))
+	return s
+}
+
+// StringStream is equivalent to writer.StringStream().
+func (arr *ArrayState) StringStream() io.WriteCloser {
+	if arr.w != nil {
+		return arr.w.StringStream()
+	}
+	return discardingStringStream{}
+}
+
+// discardingStringStream is returned by StringStream when the value is not being written.
+type discardingStringStream struct{}
+
+func (discardingStringStream) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (discardingStringStream) Close() error {
+	return nil
+}
+
+// Write escapes and writes part of the string content.
+func (s *stringStream) Write(data []byte) (int, error) {
+	if s.w.err != nil {
+		return 0, s.w.err
+	}
+	if s.closed {
+		return 0, errStringStreamClosed
+	}
+	n := len(data)
+	s.buf = s.buf[:0]
+	if s.nPending > 0 {
+		// Complete the character that was split across writes, if possible.
+		for s.nPending < utf8.UTFMax && len(data) > 0 && !utf8.FullRune(s.pending[:s.nPending]) {
+			s.pending[s.nPending] = data[0]
+			s.nPending++
+			data = data[1:]
+		}
+		if !utf8.FullRune(s.pending[:s.nPending]) {
+			return n, nil
+		}
+		if !s.appendChunk(s.pending[:s.nPending]) {
+			return 0, s.w.err
+		}
+		s.nPending = 0
+	}
+	if tail := incompleteRuneStart(data); tail < len(data) {
+		s.nPending = copy(s.pending[:], data[tail:])
+		data = data[:tail]
+	}
+	if !s.appendChunk(data) {
+		return 0, s.w.err
+	}
+	return n, s.flushChunks()
+}
+
+// Close writes the closing quote of the string. If the content ended with an incomplete multi-byte
+// character, it is treated as invalid UTF-8.
+func (s *stringStream) Close() error {
+	if s.w.err != nil || s.closed {
+		return s.w.err
+	}
+	s.closed = true
+	s.buf = s.buf[:0]
+	if !s.appendChunk(s.pending[:s.nPending]) {
+		return s.w.err
+	}
+	s.nPending = 0
+	s.buf = append(s.buf, '"')
+	return s.flushChunks()
+}
+
+// appendChunk escapes part of the content into s.buf. It returns false if the content contained
+// invalid UTF-8 and the Writer's policy is InvalidUTF8Fail, in which case it has set the error state.
+func (s *stringStream) appendChunk(data []byte) bool {
+	if s.w.enc != nil && s.w.enc.rejectInvalidUTF8 && !utf8.Valid(data) {
+		s.w.AddError(InvalidUTF8Error{Offset: s.offset + invalidUTF8Offset(data)})
+		return false
+	}
+	s.buf = appendEscapedString(s.buf, data, s.escaping)
+	s.offset += len(data)
+	return true
+}
+
+func (s *stringStream) flushChunks() error {
+	if len(s.buf) > 0 {
+		s.w.AddError(s.w.tw.Raw(s.buf))
+	}
+	return s.w.err
+}
+
+// incompleteRuneStart returns the position of a multi-byte character at the end of the data that
+// is not complete, or len(data) if there is none.
+func incompleteRuneStart(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(data)
+}
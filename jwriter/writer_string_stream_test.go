@@ -0,0 +1,175 @@
+package jwriter
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringStream(t *testing.T) {
+	w := NewWriter()
+	obj := w.Object()
+	s := obj.Name("text").StringStream()
+	for _, part := range []string{"line 1\n", "", "say \"hi\"", " tab:\t"} {
+		n, err := s.Write([]byte(part))
+		require.NoError(t, err)
+		assert.Equal(t, len(part), n)
+	}
+	require.NoError(t, s.Close())
+	obj.Name("next").Int(1)
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"text":"line 1\nsay \"hi\" tab:\t","next":1}`, string(w.Bytes()))
+}
+
+func TestStringStreamMatchesString(t *testing.T) {
+	value := "aé€😀\u2028<&>\x01\xffz"
+	for _, p := range []struct {
+		name  string
+		setUp func(*Writer)
+	}{
+		{"default", func(*Writer) {}},
+		{"escape non-ASCII", func(w *Writer) { w.SetStringEscaping(EscapeNonASCII) }},
+		{"escape like encoding/json", func(w *Writer) { w.SetStringEscaping(EscapeLikeEncodingJSON) }},
+		{"canonical", func(w *Writer) { w.SetCanonical() }},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			expectedWriter := NewWriter()
+			p.setUp(&expectedWriter)
+			expectedWriter.String(value)
+			expected := string(expectedWriter.Bytes())
+
+			// split the value at every possible position, including within multi-byte characters
+			for i := 0; i <= len(value); i++ {
+				w := NewWriter()
+				p.setUp(&w)
+				s := w.StringStream()
+				_, _ = s.Write([]byte(value[:i]))
+				_, _ = s.Write([]byte(value[i:]))
+				require.NoError(t, s.Close())
+				require.NoError(t, w.Error())
+				assert.Equal(t, expected, string(w.Bytes()), "split at %d", i)
+			}
+		})
+	}
+}
+
+func TestStringStreamWithOneByteWrites(t *testing.T) {
+	value := "é😀\xf0\x9f"
+	w := NewWriter()
+	arr := w.Array()
+	s := arr.StringStream()
+	for i := 0; i < len(value); i++ {
+		_, err := s.Write([]byte{value[i]})
+		require.NoError(t, err)
+	}
+	require.NoError(t, s.Close())
+	arr.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `["é😀\ufffd\ufffd"]`, string(w.Bytes()))
+}
+
+func TestStringStreamWithIOCopy(t *testing.T) {
+	text := strings.Repeat("abc€\n", 1000)
+	var buf bytes.Buffer
+	w := NewStreamingWriter(&buf, 100)
+	s := w.StringStream()
+	_, err := io.Copy(s, strings.NewReader(text))
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+	require.NoError(t, w.Flush())
+
+	expected := NewWriter()
+	expected.String(text)
+	assert.Equal(t, string(expected.Bytes()), buf.String())
+}
+
+func TestStringStreamInvalidUTF8FailPolicy(t *testing.T) {
+	t.Run("in one write", func(t *testing.T) {
+		w := NewWriter()
+		w.SetInvalidUTF8Policy(InvalidUTF8Fail)
+		s := w.StringStream()
+		_, err := s.Write([]byte("abc"))
+		require.NoError(t, err)
+		_, err = s.Write([]byte("d\xffe"))
+		assert.Equal(t, InvalidUTF8Error{Offset: 4}, err)
+		assert.Equal(t, err, s.Close())
+	})
+
+	t.Run("split across writes", func(t *testing.T) {
+		w := NewWriter()
+		w.SetInvalidUTF8Policy(InvalidUTF8Fail)
+		s := w.StringStream()
+		_, err := s.Write([]byte("a\xe2\x82"))
+		require.NoError(t, err)
+		_, err = s.Write([]byte("b"))
+		assert.Equal(t, InvalidUTF8Error{Offset: 1}, err)
+	})
+
+	t.Run("incomplete at end", func(t *testing.T) {
+		w := NewWriter()
+		w.SetInvalidUTF8Policy(InvalidUTF8Fail)
+		s := w.StringStream()
+		_, err := s.Write([]byte("ab\xe2"))
+		require.NoError(t, err)
+		assert.Equal(t, InvalidUTF8Error{Offset: 2}, s.Close())
+	})
+}
+
+func TestStringStreamAfterClose(t *testing.T) {
+	w := NewWriter()
+	s := w.StringStream()
+	require.NoError(t, s.Close())
+	require.NoError(t, s.Close())
+	_, err := s.Write([]byte("x"))
+	assert.Equal(t, errStringStreamClosed, err)
+	assert.Equal(t, `""`, string(w.Bytes()))
+}
+
+func TestStringStreamOnFailedWriter(t *testing.T) {
+	w := NewWriter()
+	w.AddError(assert.AnError)
+	s := w.StringStream()
+	_, err := s.Write([]byte("x"))
+	assert.Equal(t, assert.AnError, err)
+	assert.Equal(t, assert.AnError, s.Close())
+}
+
+func TestStringStreamForValueThatIsNotWritten(t *testing.T) {
+	assertDiscarded := func(t *testing.T, s io.WriteCloser) {
+		n, err := s.Write([]byte("abc"))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.NoError(t, s.Close())
+	}
+
+	t.Run("zero ArrayState", func(t *testing.T) {
+		var arr ArrayState
+		assertDiscarded(t, arr.StringStream())
+	})
+
+	t.Run("property omitted with Maybe", func(t *testing.T) {
+		w := NewWriter()
+		obj := w.Object()
+		assertDiscarded(t, obj.Maybe("a", false).StringStream())
+		obj.End()
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{}`, string(w.Bytes()))
+	})
+
+	t.Run("dropped duplicate property", func(t *testing.T) {
+		w := NewWriter()
+		w.SetDuplicateNamePolicy(DuplicateNamesDropLater)
+		obj := w.Object()
+		obj.Name("a").String("x")
+		assertDiscarded(t, obj.Name("a").StringStream())
+		obj.Name("b").String("y")
+		obj.End()
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"a":"x","b":"y"}`, string(w.Bytes()))
+	})
+}
@@ -0,0 +1,76 @@
+package jwriter
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// InvalidUTF8Policy specifies what a Writer does with a string or property name that is not valid
+// UTF-8. See Writer.SetInvalidUTF8Policy.
+type InvalidUTF8Policy int
+
+const (
+	// InvalidUTF8Replace means that each invalid byte is replaced with the Unicode replacement
+	// character U+FFFD, as json.Marshal does. This is the default.
+	InvalidUTF8Replace InvalidUTF8Policy = iota
+
+	// InvalidUTF8Fail means that the Writer enters a failed state with an InvalidUTF8Error, and
+	// the string is not written.
+	InvalidUTF8Fail
+)
+
+// InvalidUTF8Error is returned if a Writer is given a string that is not valid UTF-8, and its
+// policy is InvalidUTF8Fail.
+type InvalidUTF8Error struct {
+	// Offset is the position of the first invalid byte within the string.
+	Offset int
+
+	// PropertyName is true if the string was an object property name rather than a string value.
+	PropertyName bool
+}
+
+// Error returns a description of the error.
+func (e InvalidUTF8Error) Error() string {
+	what := "string"
+	if e.PropertyName {
+		what = "property name"
+	}
+	return fmt.Sprintf("%s contains invalid UTF-8 at byte offset %d", what, e.Offset)
+}
+
+// SetInvalidUTF8Policy specifies what the Writer should do with a string or property name that is
+// not valid UTF-8. The default is InvalidUTF8Replace.
+//
+// JSON text must be encoded in UTF-8, but a Go string can contain arbitrary bytes. Replacing the
+// invalid bytes ensures that the output can be parsed, but it changes the data; InvalidUTF8Fail
+// is useful if the data should never contain invalid UTF-8, so that doing so indicates a bug.
+// Checking for this requires an extra pass over each string.
+//
+// This does not apply to values written with Raw.
+func (w *Writer) SetInvalidUTF8Policy(policy InvalidUTF8Policy) {
+	w.encoding().rejectInvalidUTF8 = policy == InvalidUTF8Fail
+}
+
+// checkUTF8 returns true if the string can be written. If it is not valid UTF-8 and the policy is
+// InvalidUTF8Fail, it sets the error state and returns false.
+func (w *Writer) checkUTF8(s string, propertyName bool) bool {
+	if w.enc == nil || !w.enc.rejectInvalidUTF8 || utf8.ValidString(s) {
+		return true
+	}
+	w.AddError(InvalidUTF8Error{Offset: invalidUTF8Offset(s), PropertyName: propertyName})
+	return false
+}
+
+// invalidUTF8Offset returns the position of the first invalid UTF-8 byte, which the caller has
+// already determined to exist.
+func invalidUTF8Offset[S string | []byte](s S) int {
+	offset := 0
+	for offset < len(s) {
+		r, size := decodeRune(s[offset:])
+		if r == utf8.RuneError && size == 1 {
+			break
+		}
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,92 @@
+package jwriter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invalidUTF8Strings = []string{ //nolint:gochecknoglobals
+	"\xff", "a\xffb", "\xc3", "a\xc3(b", "\xed\xa0\x80", "\xf0\x9f\x98", "é\xffé",
+}
+
+// Depending on the Go version, encoding/json writes U+FFFD either escaped or as a literal
+// character, so we compare the decoded strings rather than the output.
+func TestInvalidUTF8IsReplacedLikeEncodingJSON(t *testing.T) {
+	for _, value := range invalidUTF8Strings {
+		for _, escaping := range []StringEscaping{EscapeLikeEncodingJSON, EscapeNonASCII} {
+			t.Run(value, func(t *testing.T) {
+				marshaled, err := json.Marshal(map[string]string{value: value})
+				require.NoError(t, err)
+				var expected map[string]string
+				require.NoError(t, json.Unmarshal(marshaled, &expected))
+
+				w := NewWriter()
+				w.SetStringEscaping(escaping)
+				obj := w.Object()
+				obj.Name(value).String(value)
+				obj.End()
+				require.NoError(t, w.Error())
+				var actual map[string]string
+				require.NoError(t, json.Unmarshal(w.Bytes(), &actual))
+				assert.Equal(t, expected, actual)
+			})
+		}
+	}
+}
+
+func TestInvalidUTF8IsReplacedByDefault(t *testing.T) {
+	w := NewWriter()
+	obj := w.Object()
+	obj.Name("a\xffb").String("c\xffd")
+	obj.End()
+	require.NoError(t, w.Error())
+	assert.Equal(t, `{"a\ufffdb":"c\ufffdd"}`, string(w.Bytes()))
+}
+
+func TestInvalidUTF8IsReplacedInCanonicalMode(t *testing.T) {
+	w := NewWriter()
+	w.SetCanonical()
+	w.String("a\xffb")
+	require.NoError(t, w.Error())
+	assert.Equal(t, "\"a\ufffdb\"", string(w.Bytes()))
+}
+
+func TestInvalidUTF8FailPolicy(t *testing.T) {
+	t.Run("string value", func(t *testing.T) {
+		w := NewWriter()
+		w.SetInvalidUTF8Policy(InvalidUTF8Fail)
+		arr := w.Array()
+		arr.String("ok")
+		arr.String("abc\xff")
+		arr.End()
+		assert.Equal(t, InvalidUTF8Error{Offset: 3}, w.Error())
+	})
+
+	t.Run("property name", func(t *testing.T) {
+		w := NewWriter()
+		w.SetInvalidUTF8Policy(InvalidUTF8Fail)
+		obj := w.Object()
+		obj.Name("é\xc3").Int(1)
+		obj.End()
+		assert.Equal(t, InvalidUTF8Error{Offset: 2, PropertyName: true}, w.Error())
+	})
+
+	t.Run("valid strings are unaffected", func(t *testing.T) {
+		w := NewWriter()
+		w.SetInvalidUTF8Policy(InvalidUTF8Fail)
+		obj := w.Object()
+		obj.Name("é").String("😀")
+		obj.End()
+		require.NoError(t, w.Error())
+		assert.Equal(t, `{"é":"😀"}`, string(w.Bytes()))
+	})
+}
+
+func TestInvalidUTF8ErrorMessage(t *testing.T) {
+	assert.Equal(t, "string contains invalid UTF-8 at byte offset 3", InvalidUTF8Error{Offset: 3}.Error())
+	assert.Equal(t, "property name contains invalid UTF-8 at byte offset 0",
+		InvalidUTF8Error{PropertyName: true}.Error())
+}
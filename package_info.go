@@ -1,6 +1,11 @@
 // Package jsonstream provides a fast streaming JSON encoding and decoding mechanism.
 //
-// The base package is empty; see the jreader and jwriter subpackages.
+// The base package is empty; see the jreader and jwriter subpackages. The jvalue subpackage
+// provides an immutable representation of arbitrary JSON values that is built on them, and the
+// jpointer subpackage implements JSON Pointer expressions for locating values within a document.
+// The jpath subpackage evaluates JSONPath queries against streaming input, the jstream
+// subpackage copies values directly from a reader to a writer, and the jhash subpackage computes
+// hashes of JSON values that do not depend on the order of object members.
 //
 // In the default implementation, these packages have no external dependencies. Setting the build
 // tag "launchdarkly_easyjson" causes them to use https://github.com/mailru/easyjson as the